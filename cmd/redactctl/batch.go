@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/censgate/redact/pkg/redaction"
+)
+
+// maxBatchRecord bounds the size of one batch record, matching the engine's
+// default text length limit
+const maxBatchRecord = 1024 * 1024
+
+// batchRecord is one line of --batch --format json output. The original
+// text is left out so the stream can be passed on as is.
+type batchRecord struct {
+	Record       int              `json:"record"`
+	RedactedText string           `json:"redacted_text"`
+	Token        string           `json:"token,omitempty"`
+	Redactions   []batchRedaction `json:"redactions"`
+}
+
+// batchRedaction is a redaction in batch JSON output, with offsets into its
+// record
+type batchRedaction struct {
+	Type        redaction.Type `json:"type"`
+	Replacement string         `json:"replacement"`
+	Start       int            `json:"start"`
+	End         int            `json:"end"`
+	Confidence  float64        `json:"confidence"`
+}
+
+// redactBatch runs --batch over the command's input and output: the
+// arguments, --input or stdin, written to --output or stdout. The json
+// format emits JSON lines.
+func redactBatch(ctx context.Context, engine *redaction.Engine, args []string) error {
+	delimiter, err := parseDelimiter(batchDelimiter)
+	if err != nil {
+		return err
+	}
+
+	var jsonLines bool
+	switch outputFormat {
+	case "json":
+		jsonLines = true
+	case "text":
+	default:
+		return fmt.Errorf("batch mode supports the text and json formats, not %q", outputFormat)
+	}
+
+	var input io.Reader = os.Stdin
+	if len(args) > 0 {
+		input = strings.NewReader(strings.Join(args, " "))
+	} else if inputFile != "" {
+		file, err := os.Open(inputFile)
+		if err != nil {
+			return fmt.Errorf("error reading input file: %w", err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	var output io.Writer = os.Stdout
+	if outputFile != "" {
+		file, err := os.OpenFile(outputFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return fmt.Errorf("error creating output file: %w", err)
+		}
+		defer file.Close()
+		output = file
+	}
+
+	records, err := runBatch(ctx, engine, strategyName, input, output, delimiter, jsonLines)
+	if err != nil {
+		return err
+	}
+	if showRedactStats {
+		fmt.Fprintf(os.Stderr, "Redacted %d records\n", records)
+	}
+	return nil
+}
+
+// parseDelimiter interprets escapes such as \n and \t in a --delimiter value
+func parseDelimiter(value string) (string, error) {
+	delimiter, err := strconv.Unquote(`"` + value + `"`)
+	if err != nil {
+		delimiter = value
+	}
+	if delimiter == "" {
+		return "", fmt.Errorf("batch delimiter cannot be empty")
+	}
+	return delimiter, nil
+}
+
+// splitOn splits input into records separated by delimiter. A newline
+// delimiter also strips a trailing carriage return, like bufio.ScanLines.
+func splitOn(delimiter string) bufio.SplitFunc {
+	if delimiter == "\n" {
+		return bufio.ScanLines
+	}
+	separator := []byte(delimiter)
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+		if i := bytes.Index(data, separator); i >= 0 {
+			return i + len(separator), data[:i], nil
+		}
+		if atEOF {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	}
+}
+
+// runBatch redacts each delimited record of r independently, so every record
+// gets its own restore token, and streams the results to w as they are
+// produced: the redacted records rejoined by the delimiter, or one JSON
+// object per line when jsonLines is set. Records are redacted like redactText
+// does with strategy. It returns the number of records.
+func runBatch(ctx context.Context, engine *redaction.Engine, strategy string, r io.Reader, w io.Writer, delimiter string, jsonLines bool) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxBatchRecord)
+	scanner.Split(splitOn(delimiter))
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+
+	records := 0
+	for scanner.Scan() {
+		records++
+		result, err := redactText(ctx, engine, strategy, scanner.Text())
+		if err != nil {
+			return records, fmt.Errorf("record %d: %w", records, err)
+		}
+
+		if jsonLines {
+			err = encoder.Encode(newBatchRecord(records, result))
+		} else {
+			_, err = io.WriteString(w, result.RedactedText+delimiter)
+		}
+		if err != nil {
+			return records, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return records, fmt.Errorf("error reading batch input: %w", err)
+	}
+	return records, nil
+}
+
+// newBatchRecord builds the JSON output of one batch record
+func newBatchRecord(record int, result *redaction.Result) batchRecord {
+	out := batchRecord{
+		Record:       record,
+		RedactedText: result.RedactedText,
+		Token:        result.Token,
+		Redactions:   make([]batchRedaction, 0, len(result.Redactions)),
+	}
+	for _, r := range result.Redactions {
+		out.Redactions = append(out.Redactions, batchRedaction{
+			Type:        r.Type,
+			Replacement: r.Replacement,
+			Start:       r.Start,
+			End:         r.End,
+			Confidence:  r.Confidence,
+		})
+	}
+	return out
+}
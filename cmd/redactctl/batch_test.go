@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/censgate/redact/pkg/redaction"
+)
+
+func TestRunBatch(t *testing.T) {
+	input := "mail a@example.com\nnothing here\r\nSSN 123-45-6789\n"
+
+	t.Run("text", func(t *testing.T) {
+		var output bytes.Buffer
+		records, err := runBatch(context.Background(), redaction.NewEngine(), "", strings.NewReader(input), &output, "\n", false)
+		if err != nil {
+			t.Fatalf("runBatch failed: %v", err)
+		}
+		if records != 3 {
+			t.Errorf("Expected 3 records, got %d", records)
+		}
+		want := "mail [EMAIL_REDACTED]\nnothing here\nSSN [SSN_REDACTED]\n"
+		if output.String() != want {
+			t.Errorf("Expected %q, got %q", want, output.String())
+		}
+	})
+
+	t.Run("json lines", func(t *testing.T) {
+		var output bytes.Buffer
+		if _, err := runBatch(context.Background(), redaction.NewEngine(), "", strings.NewReader(input), &output, "\n", true); err != nil {
+			t.Fatalf("runBatch failed: %v", err)
+		}
+
+		var records []batchRecord
+		scanner := bufio.NewScanner(&output)
+		for scanner.Scan() {
+			var record batchRecord
+			if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+				t.Fatalf("Invalid JSON line %q: %v", scanner.Text(), err)
+			}
+			records = append(records, record)
+		}
+		if len(records) != 3 {
+			t.Fatalf("Expected 3 JSON lines, got %d", len(records))
+		}
+
+		// Each record is redacted on its own: offsets are relative to the
+		// record and each record with findings has its own token
+		email, clean, ssn := records[0], records[1], records[2]
+		if len(email.Redactions) != 1 || email.Redactions[0].Start != 5 || email.Redactions[0].Type != redaction.TypeEmail {
+			t.Errorf("Unexpected email record: %+v", email)
+		}
+		if len(clean.Redactions) != 0 || clean.Token != "" || clean.RedactedText != "nothing here" {
+			t.Errorf("Unexpected clean record: %+v", clean)
+		}
+		if len(ssn.Redactions) != 1 || ssn.Redactions[0].Start != 4 || ssn.Record != 3 {
+			t.Errorf("Unexpected SSN record: %+v", ssn)
+		}
+		if email.Token == "" || ssn.Token == "" || email.Token == ssn.Token {
+			t.Errorf("Expected a distinct token per record, got %q and %q", email.Token, ssn.Token)
+		}
+		if strings.Contains(output.String(), "a@example.com") {
+			t.Error("Expected no original text in JSON output")
+		}
+	})
+
+	t.Run("paragraph delimiter", func(t *testing.T) {
+		delimiter, err := parseDelimiter(`\n\n`)
+		if err != nil {
+			t.Fatalf("parseDelimiter failed: %v", err)
+		}
+
+		var output bytes.Buffer
+		paragraphs := "Call 555-123-4567\nor mail a@example.com\n\nSSN 123-45-6789"
+		records, err := runBatch(context.Background(), redaction.NewEngine(), "", strings.NewReader(paragraphs), &output, delimiter, false)
+		if err != nil {
+			t.Fatalf("runBatch failed: %v", err)
+		}
+		if records != 2 {
+			t.Errorf("Expected 2 paragraphs, got %d", records)
+		}
+		want := "Call [PHONE_REDACTED]\nor mail [EMAIL_REDACTED]\n\nSSN [SSN_REDACTED]\n\n"
+		if output.String() != want {
+			t.Errorf("Expected %q, got %q", want, output.String())
+		}
+	})
+
+	if _, err := parseDelimiter(""); err == nil {
+		t.Error("Expected an empty delimiter to be rejected")
+	}
+}
@@ -3,8 +3,11 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/censgate/redact/config"
@@ -14,6 +17,7 @@ import (
 
 var (
 	testPattern string
+	typesFormat string
 )
 
 // engineCmd represents the engine command
@@ -71,6 +75,20 @@ var engineRotateCmd = &cobra.Command{
 	},
 }
 
+// engineTypesCmd lists supported redaction types
+var engineTypesCmd = &cobra.Command{
+	Use:   "types",
+	Short: "List supported redaction types",
+	Long: "Display every redaction type the engine detects, its default replacement, " +
+		"and whether detection depends on surrounding context.",
+	Run: func(_ *cobra.Command, _ []string) {
+		if err := runEngineTypes(os.Stdout, typesFormat); err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing types: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
 // engineTestCmd tests pattern matching
 var engineTestCmd = &cobra.Command{
 	Use:   "test [text]",
@@ -91,19 +109,22 @@ func init() {
 	engineCmd.AddCommand(engineCleanupCmd)
 	engineCmd.AddCommand(engineRotateCmd)
 	engineCmd.AddCommand(engineTestCmd)
+	engineCmd.AddCommand(engineTypesCmd)
+
+	// Flags for types command
+	engineTypesCmd.Flags().StringVarP(&typesFormat, "format", "f", "text", "output format (text, json)")
 
 	// Flags for test command
 	engineTestCmd.Flags().StringVar(&testPattern, "pattern", "", "test specific pattern type")
 }
 
 func runEngineStats() {
-	cfg, err := config.LoadConfig(cfgFile)
+	cfg, engine, err := loadEngine()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	engine := redaction.NewEngine()
 	stats := engine.GetRedactionStats()
 
 	fmt.Println("🔧 Redaction Engine Statistics")
@@ -155,7 +176,11 @@ func runEnginePatterns() {
 }
 
 func runEngineCleanup() {
-	engine := redaction.NewEngine()
+	_, engine, err := loadEngine()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
 	fmt.Println("🧹 Cleaning up expired tokens...")
 	removed := engine.CleanupExpiredTokens()
@@ -168,7 +193,11 @@ func runEngineCleanup() {
 }
 
 func runEngineRotate() {
-	engine := redaction.NewEngine()
+	_, engine, err := loadEngine()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
 	fmt.Println("🔄 Rotating encryption keys...")
 	if err := engine.RotateKeys(); err != nil {
@@ -181,8 +210,53 @@ func runEngineRotate() {
 	fmt.Printf("New key version: %v\n", stats["key_version"])
 }
 
-func runEngineTest(args []string) {
+// typeInfo describes a supported redaction type
+type typeInfo struct {
+	Type             redaction.Type `json:"type"`
+	Replacement      string         `json:"replacement"`
+	ContextDependent bool           `json:"context_dependent"`
+}
+
+func runEngineTypes(w io.Writer, format string) error {
 	engine := redaction.NewEngine()
+	supported := engine.GetCapabilities().SupportedTypes
+	sort.Slice(supported, func(i, j int) bool { return supported[i] < supported[j] })
+
+	types := make([]typeInfo, 0, len(supported))
+	for _, rType := range supported {
+		types = append(types, typeInfo{
+			Type:             rType,
+			Replacement:      redaction.DefaultReplacement(rType),
+			ContextDependent: redaction.IsContextDependent(rType),
+		})
+	}
+
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(types)
+	case "text":
+		fmt.Fprintf(w, "Supported redaction types (%d):\n", len(types))
+		for _, info := range types {
+			contextNote := ""
+			if info.ContextDependent {
+				contextNote = " (context-dependent)"
+			}
+			fmt.Fprintf(w, "  %-24s %s%s\n", info.Type, info.Replacement, contextNote)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+func runEngineTest(args []string) {
+	_, engine, err := loadEngine()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 	testText := strings.Join(args, " ")
 
 	fmt.Printf("🧪 Testing redaction on: %q\n", testText)
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRunEngineTypes(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := runEngineTypes(&out, "text"); err != nil {
+			t.Fatalf("runEngineTypes failed: %v", err)
+		}
+
+		for _, want := range []string{"email", "[EMAIL_REDACTED]", "uk_nhs_number", "(context-dependent)"} {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("Expected output to contain %q:\n%s", want, out.String())
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := runEngineTypes(&out, "json"); err != nil {
+			t.Fatalf("runEngineTypes failed: %v", err)
+		}
+
+		var types []typeInfo
+		if err := json.Unmarshal(out.Bytes(), &types); err != nil {
+			t.Fatalf("Invalid JSON output: %v", err)
+		}
+
+		found := make(map[string]typeInfo)
+		for _, info := range types {
+			found[string(info.Type)] = info
+		}
+		if _, ok := found["email"]; !ok {
+			t.Error("Expected email in supported types")
+		}
+		if _, ok := found["uk_nhs_number"]; !ok {
+			t.Error("Expected uk_nhs_number in supported types")
+		}
+		if !found["uk_passport_number"].ContextDependent {
+			t.Error("Expected uk_passport_number to be context-dependent")
+		}
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		if err := runEngineTypes(&bytes.Buffer{}, "xml"); err == nil {
+			t.Error("Expected an error for an unsupported format")
+		}
+	})
+}
@@ -20,6 +20,11 @@ var (
 	disableTypes    []string
 	showRedactStats bool
 	batchMode       bool
+	batchDelimiter  string
+	inputDir        string
+	outputDir       string
+	reportFile      string
+	strategyName    string
 )
 
 // redactCmd represents the redact command
@@ -40,7 +45,16 @@ Examples:
   echo "SSN: 123-45-6789" | redactctl redact --format json
   
   # Show redaction statistics
-  redactctl redact --input data.txt --stats`,
+  redactctl redact --input data.txt --stats
+
+  # Redact each line independently, streaming one JSON object per line
+  redactctl redact --input records.txt --batch --format json
+
+  # Redact a directory and write a JSON manifest of the findings
+  redactctl redact --input-dir exports/ --output-dir redacted/ --report report.json
+
+  # Replace values with format-preserving fakes instead of placeholders
+  redactctl redact --strategy format_preserving "SSN: 123-45-6789"`,
 	Run: func(_ *cobra.Command, args []string) {
 		runRedact(args)
 	},
@@ -53,24 +67,31 @@ func init() {
 	redactCmd.Flags().StringVarP(&inputFile, "input", "i", "", "input file (default: stdin)")
 	redactCmd.Flags().StringVarP(&outputFile, "output", "o", "", "output file (default: stdout)")
 	redactCmd.Flags().StringVarP(&outputFormat, "format", "f", "text", "output format (text, json, yaml)")
+	redactCmd.Flags().StringVar(&inputDir, "input-dir", "", "redact every file under this directory")
+	redactCmd.Flags().StringVar(&outputDir, "output-dir", "", "directory for redacted copies of --input-dir files")
+	redactCmd.Flags().StringVar(&reportFile, "report", "", "JSON manifest for --input-dir runs (default: stdout)")
 
 	// Redaction control flags
 	redactCmd.Flags().StringSliceVar(&enableTypes, "enable", []string{}, "enable specific redaction types")
 	redactCmd.Flags().StringSliceVar(&disableTypes, "disable", []string{}, "disable specific redaction types")
 	redactCmd.Flags().BoolVar(&showRedactStats, "stats", false, "show redaction statistics")
-	redactCmd.Flags().BoolVar(&batchMode, "batch", false, "process input in batch mode")
+	redactCmd.Flags().StringVar(&strategyName, "strategy", "", `replacement strategy, e.g. format_preserving, or "default" for each type's default`)
+	redactCmd.Flags().BoolVar(&batchMode, "batch", false, "redact each delimited record of the input independently")
+	redactCmd.Flags().StringVar(&batchDelimiter, "delimiter", `\n`, `record delimiter for --batch, e.g. "\n\n" for paragraphs`)
 }
 
 func runRedact(args []string) {
-	// Load configuration
-	cfg, err := config.LoadConfig(cfgFile)
+	// Load configuration and the engine it describes
+	cfg, engine, err := loadEngine()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Initialize redaction engine
-	engine := redaction.NewEngine()
+	if err := validateStrategy(engine.StrategyRegistry(), strategyName); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
 	// Configure enabled types based on flags and config
 	if len(enableTypes) > 0 {
@@ -81,6 +102,30 @@ func runRedact(args []string) {
 		}
 	}
 
+	// Redact a whole directory and report on it
+	if inputDir != "" {
+		report, err := runBatchReport(context.Background(), engine, strategyName, inputDir, outputDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Batch redaction failed: %v\n", err)
+			os.Exit(1)
+		}
+		if err := writeBatchReport(report, reportFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing report: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Redacted %d files, %d redactions\n", report.TotalFiles, report.TotalRedactions)
+		return
+	}
+
+	// Redact each record independently, streaming the results
+	if batchMode {
+		if err := redactBatch(context.Background(), engine, args); err != nil {
+			fmt.Fprintf(os.Stderr, "Batch redaction failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Get input text
 	var inputText string
 	if len(args) > 0 {
@@ -96,19 +141,11 @@ func runRedact(args []string) {
 		inputText = string(data)
 	} else {
 		// Read from stdin
-		if batchMode {
-			inputText = readBatchInput()
-		} else {
-			inputText = readStdinInput()
-		}
+		inputText = readStdinInput()
 	}
 
 	// Perform redaction
-	result, err := engine.RedactText(context.Background(), &redaction.Request{
-		Text:       inputText,
-		Mode:       redaction.ModeReplace,
-		Reversible: true,
-	})
+	result, err := redactText(context.Background(), engine, strategyName, inputText)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Redaction failed: %v\n", err)
 		os.Exit(1)
@@ -126,6 +163,17 @@ func runRedact(args []string) {
 	}
 }
 
+// redactText redacts text in replace mode, or with the named replacement
+// strategy when one is given, keeping a restore token
+func redactText(ctx context.Context, engine *redaction.Engine, strategy, text string) (*redaction.Result, error) {
+	return engine.RedactText(ctx, &redaction.Request{
+		Text:       text,
+		Mode:       redaction.ModeReplace,
+		Strategy:   strategy,
+		Reversible: true,
+	})
+}
+
 func readStdinInput() string {
 	// Check if stdin is a terminal (interactive) or a pipe/file
 	stat, _ := os.Stdin.Stat()
@@ -156,19 +204,6 @@ func readStdinInput() string {
 	return strings.Join(lines, "\n")
 }
 
-func readBatchInput() string {
-	var lines []string
-	scanner := bufio.NewScanner(os.Stdin)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-	}
-	if err := scanner.Err(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading batch input: %v\n", err)
-		os.Exit(1)
-	}
-	return strings.Join(lines, "\n")
-}
-
 func outputResults(result *redaction.Result, _ *config.Config) error {
 	var output string
 	var err error
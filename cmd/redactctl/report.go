@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/censgate/redact/pkg/redaction"
+)
+
+// maxTopFiles bounds the files listed as having the most findings
+const maxTopFiles = 10
+
+// fileReport summarises the redactions of one file in a batch run
+type fileReport struct {
+	Path string `json:"path"`
+	*redaction.Summary
+}
+
+// batchReport is the manifest of a directory redaction run
+type batchReport struct {
+	GeneratedAt     time.Time              `json:"generated_at"`
+	InputDir        string                 `json:"input_dir"`
+	TotalFiles      int                    `json:"total_files"`
+	TotalRedactions int                    `json:"total_redactions"`
+	ByType          map[redaction.Type]int `json:"by_type"`
+	TopFiles        []fileReport           `json:"top_files"`
+	Files           []fileReport           `json:"files"`
+	EngineStats     map[string]interface{} `json:"engine_stats"`
+}
+
+// runBatchReport redacts every regular file under inputDir, writing redacted
+// copies under outputDir (when set), and returns the aggregate manifest.
+// Files are redacted in replace mode, or with strategy when one is given. An
+// outputDir inside inputDir is skipped, so earlier output is never re-read.
+func runBatchReport(ctx context.Context, engine *redaction.Engine, strategy, inputDir, outputDir string) (*batchReport, error) {
+	var absOutput string
+	if outputDir != "" {
+		absInput, err := filepath.Abs(inputDir)
+		if err != nil {
+			return nil, err
+		}
+		if absOutput, err = filepath.Abs(outputDir); err != nil {
+			return nil, err
+		}
+		if absOutput == absInput {
+			return nil, fmt.Errorf("output directory %s must differ from the input directory", outputDir)
+		}
+	}
+
+	report := &batchReport{
+		GeneratedAt: time.Now(),
+		InputDir:    inputDir,
+		ByType:      make(map[redaction.Type]int),
+		Files:       []fileReport{},
+	}
+
+	err := filepath.WalkDir(inputDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() && absOutput != "" {
+			if absPath, err := filepath.Abs(path); err == nil && absPath == absOutput {
+				return fs.SkipDir
+			}
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", path, err)
+		}
+
+		result, err := engine.RedactText(ctx, &redaction.Request{
+			Text:     string(data),
+			Mode:     redaction.ModeReplace,
+			Strategy: strategy,
+		})
+		if err != nil {
+			return fmt.Errorf("error redacting %s: %w", path, err)
+		}
+
+		relPath, err := filepath.Rel(inputDir, path)
+		if err != nil {
+			return err
+		}
+
+		if outputDir != "" {
+			target := filepath.Join(outputDir, relPath)
+			if err := os.MkdirAll(filepath.Dir(target), 0750); err != nil {
+				return err
+			}
+			if err := os.WriteFile(target, []byte(result.RedactedText), 0600); err != nil {
+				return fmt.Errorf("error writing %s: %w", target, err)
+			}
+		}
+
+		summary := result.Summary()
+		report.Files = append(report.Files, fileReport{Path: relPath, Summary: summary})
+		report.TotalFiles++
+		report.TotalRedactions += summary.Total
+		for rType, count := range summary.ByType {
+			report.ByType[rType] += count
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report.TopFiles = topFiles(report.Files, maxTopFiles)
+	report.EngineStats = engine.GetRedactionStats()
+
+	return report, nil
+}
+
+// topFiles returns the files with the most redactions, most first
+func topFiles(files []fileReport, limit int) []fileReport {
+	var withFindings []fileReport
+	for _, file := range files {
+		if file.Total > 0 {
+			withFindings = append(withFindings, file)
+		}
+	}
+
+	sort.SliceStable(withFindings, func(i, j int) bool {
+		return withFindings[i].Total > withFindings[j].Total
+	})
+
+	if len(withFindings) > limit {
+		withFindings = withFindings[:limit]
+	}
+	return withFindings
+}
+
+// writeBatchReport writes the manifest as JSON to path, or stdout when empty
+func writeBatchReport(report *batchReport, path string) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if path == "" {
+		fmt.Println(string(data))
+		return nil
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
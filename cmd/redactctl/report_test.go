@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/censgate/redact/pkg/redaction"
+)
+
+func TestRunBatchReport(t *testing.T) {
+	inputDir := t.TempDir()
+	outputDir := t.TempDir()
+
+	files := map[string]string{
+		"a.txt":        "mail a@example.com or b@example.com",
+		"nested/b.txt": "SSN 123-45-6789",
+		"clean.txt":    "nothing here",
+	}
+	for name, content := range files {
+		path := filepath.Join(inputDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := runBatchReport(context.Background(), redaction.NewEngine(), "", inputDir, outputDir)
+	if err != nil {
+		t.Fatalf("runBatchReport failed: %v", err)
+	}
+
+	if report.TotalFiles != 3 || report.TotalRedactions != 3 {
+		t.Errorf("Expected 3 files and 3 redactions, got %d and %d", report.TotalFiles, report.TotalRedactions)
+	}
+	if report.ByType[redaction.TypeEmail] != 2 || report.ByType[redaction.TypeSSN] != 1 {
+		t.Errorf("Unexpected counts by type: %v", report.ByType)
+	}
+	if len(report.TopFiles) != 2 || report.TopFiles[0].Path != "a.txt" {
+		t.Errorf("Expected a.txt to have the most findings, got %+v", report.TopFiles)
+	}
+
+	redacted, err := os.ReadFile(filepath.Join(outputDir, "nested", "b.txt"))
+	if err != nil || strings.Contains(string(redacted), "123-45-6789") {
+		t.Errorf("Expected a redacted copy in the output directory, got %q (%v)", redacted, err)
+	}
+
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	if err := writeBatchReport(report, reportPath); err != nil {
+		t.Fatalf("writeBatchReport failed: %v", err)
+	}
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest map[string]interface{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("Invalid manifest JSON: %v", err)
+	}
+	if manifest["total_redactions"] != float64(3) {
+		t.Errorf("Expected total_redactions 3 in manifest, got %v", manifest["total_redactions"])
+	}
+}
+
+func TestRunBatchReportNestedOutput(t *testing.T) {
+	inputDir := t.TempDir()
+	outputDir := filepath.Join(inputDir, "redacted")
+
+	if err := os.WriteFile(filepath.Join(inputDir, "a.txt"), []byte("SSN 123-45-6789"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// A second run must not pick up the first run's output
+	for run := 1; run <= 2; run++ {
+		report, err := runBatchReport(context.Background(), redaction.NewEngine(), "", inputDir, outputDir)
+		if err != nil {
+			t.Fatalf("run %d: runBatchReport failed: %v", run, err)
+		}
+		if report.TotalFiles != 1 {
+			t.Errorf("run %d: expected 1 file, got %d (%+v)", run, report.TotalFiles, report.Files)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "redacted")); !os.IsNotExist(err) {
+		t.Errorf("Expected no nested output directory, got %v", err)
+	}
+
+	if _, err := runBatchReport(context.Background(), redaction.NewEngine(), "", inputDir, inputDir); err == nil {
+		t.Error("Expected an error when the output directory is the input directory")
+	}
+}
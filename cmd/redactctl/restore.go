@@ -6,15 +6,15 @@ import (
 	"os"
 	"strings"
 
-	"github.com/censgate/redact/config"
 	"github.com/censgate/redact/pkg/redaction"
 	"github.com/spf13/cobra"
 )
 
 var (
-	token      string
-	tokenFile  string
-	restoreOut string
+	token        string
+	tokenFile    string
+	restoreInput string
+	restoreOut   string
 )
 
 // restoreCmd represents the restore command
@@ -33,7 +33,11 @@ Examples:
   redactctl restore --token-file tokens.txt
   
   # Restore and save to file
-  redactctl restore abc123def456 --output original.txt`,
+  redactctl restore abc123def456 --output original.txt
+
+  # Restore every in-text token marker of a tokenized document
+  redactctl restore --input redacted.txt
+  cat redacted.txt | redactctl restore`,
 	Args: cobra.MaximumNArgs(1),
 	Run: func(_ *cobra.Command, args []string) {
 		runRestore(args)
@@ -46,14 +50,16 @@ func init() {
 	// Restore-specific flags
 	restoreCmd.Flags().StringVar(&token, "token", "", "redaction token to restore")
 	restoreCmd.Flags().StringVar(&tokenFile, "token-file", "", "file containing redaction token")
+	restoreCmd.Flags().StringVarP(&restoreInput, "input", "i", "",
+		"redacted document whose in-text tokens are restored (default: stdin)")
 	restoreCmd.Flags().StringVarP(&restoreOut, "output", "o", "", "output file for restored text (default: stdout)")
 }
 
 func runRestore(args []string) {
-	// Load configuration
-	cfg, err := config.LoadConfig(cfgFile)
+	// Load configuration and the engine it describes
+	cfg, engine, err := loadEngine()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
@@ -70,19 +76,24 @@ func runRestore(args []string) {
 			os.Exit(1)
 		}
 		targetToken = string(data)
+	} else if restoreInput != "" {
+		data, err := os.ReadFile(restoreInput)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading input file: %v\n", err)
+			os.Exit(1)
+		}
+		targetToken = string(data)
 	} else {
-		fmt.Fprintf(os.Stderr, "Error: No token provided. Use --token, --token-file, or provide token as argument\n")
-		os.Exit(1)
+		targetToken = readStdinInput()
 	}
 
-	// Trim whitespace from token
-	targetToken = strings.TrimSpace(targetToken)
-
-	// Initialize redaction engine
-	engine := redaction.NewEngine()
+	if strings.TrimSpace(targetToken) == "" {
+		fmt.Fprintf(os.Stderr, "Error: No token provided. Use --token, --token-file, --input, or provide token as argument\n")
+		os.Exit(1)
+	}
 
 	// Restore original text
-	restoreResult, err := engine.RestoreText(context.Background(), targetToken)
+	restoreResult, err := restoreDocument(context.Background(), engine, targetToken)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error restoring text: %v\n", err)
 		os.Exit(1)
@@ -101,7 +112,24 @@ func runRestore(args []string) {
 
 	// Log success
 	if logLevel == "debug" || cfg.Logging.Level == "debug" {
-		fmt.Fprintf(os.Stderr, "Successfully restored %d characters from token: %s\n",
-			len(restoreResult.OriginalText), targetToken)
+		fmt.Fprintf(os.Stderr, "Successfully restored %d characters\n", len(restoreResult.OriginalText))
 	}
 }
+
+// restoreDocument restores input that is either a single whole-document token
+// or a redacted document carrying in-text token markers
+func restoreDocument(ctx context.Context, engine *redaction.Engine, input string) (*redaction.RestoreResult, error) {
+	if !redaction.ContainsTokenMarkers(input) {
+		return engine.RestoreText(ctx, strings.TrimSpace(input))
+	}
+
+	result, err := engine.RestoreInText(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if unresolved, ok := result.Metadata["unresolved"].([]string); ok && len(unresolved) > 0 {
+		return nil, fmt.Errorf("invalid or expired tokens: %s", strings.Join(unresolved, ", "))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/censgate/redact/pkg/redaction"
+)
+
+func TestRestoreDocument(t *testing.T) {
+	ctx := context.Background()
+	engine := redaction.NewEngine()
+	original := "Email john@example.com and SSN 123-45-6789 on file"
+
+	result, err := engine.RedactText(ctx, &redaction.Request{
+		Text:       original,
+		Mode:       redaction.ModeTokenize,
+		Reversible: true,
+	})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+
+	t.Run("Document with two token markers", func(t *testing.T) {
+		if strings.Count(result.RedactedText, "[TOKEN_") != 2 {
+			t.Fatalf("Expected two token markers, got %q", result.RedactedText)
+		}
+
+		restored, err := restoreDocument(ctx, engine, result.RedactedText)
+		if err != nil {
+			t.Fatalf("restoreDocument failed: %v", err)
+		}
+		if restored.OriginalText != original {
+			t.Errorf("Expected %q, got %q", original, restored.OriginalText)
+		}
+	})
+
+	t.Run("Single whole-document token", func(t *testing.T) {
+		restored, err := restoreDocument(ctx, engine, result.Token+"\n")
+		if err != nil {
+			t.Fatalf("restoreDocument failed: %v", err)
+		}
+		if restored.OriginalText != original {
+			t.Errorf("Expected %q, got %q", original, restored.OriginalText)
+		}
+	})
+
+	t.Run("Unknown marker", func(t *testing.T) {
+		if _, err := restoreDocument(ctx, engine, "Hi [TOKEN_0123456789ABCDEF]"); err == nil {
+			t.Error("Expected an error for an unknown token marker")
+		}
+	})
+}
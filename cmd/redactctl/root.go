@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/censgate/redact/config"
+	"github.com/censgate/redact/pkg/redaction"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -71,3 +73,19 @@ func initConfig() {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
 }
+
+// loadEngine loads the configuration and builds the engine it describes, so
+// every command honours the configured strategies and secrets
+func loadEngine() (*config.Config, *redaction.Engine, error) {
+	cfg, err := config.LoadConfig(cfgFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading config: %w", err)
+	}
+
+	engine, err := config.NewEngine(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("building engine: %w", err)
+	}
+
+	return cfg, engine, nil
+}
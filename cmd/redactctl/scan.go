@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/censgate/redact/pkg/redaction"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scanInput  string
+	scanFailOn []string
+)
+
+// scanCmd represents the scan command
+var scanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: "Scan input for PII/PHI and fail if any is found",
+	Long: `Scan a file or stdin for sensitive data without producing redacted output.
+The command exits with status 1 when sensitive data is found, making it usable
+as a CI or pre-commit gate. A summary of the findings is printed to stderr.
+
+Examples:
+  # Fail if anything sensitive is found
+  redactctl scan --input config.env
+
+  # Fail only on emails and SSNs
+  redactctl scan --input export.csv --fail-on email,ssn
+
+  # Opt-in types such as social_handle and high_entropy are scanned when listed
+  redactctl scan --input notes.md --fail-on high_entropy`,
+	Run: func(_ *cobra.Command, _ []string) {
+		var text string
+		if scanInput != "" {
+			data, err := os.ReadFile(scanInput)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading input file: %v\n", err)
+				os.Exit(2)
+			}
+			text = string(data)
+		} else {
+			text = readStdinInput()
+		}
+
+		_, engine, err := loadEngine()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+
+		code, err := runScan(context.Background(), engine, text, scanFailOn, os.Stderr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Scan failed: %v\n", err)
+			os.Exit(2)
+		}
+		os.Exit(code)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(scanCmd)
+
+	scanCmd.Flags().StringVarP(&scanInput, "input", "i", "", "input file (default: stdin)")
+	scanCmd.Flags().StringSliceVar(&scanFailOn, "fail-on", []string{},
+		"redaction types that fail the scan (default: any type)")
+}
+
+// runScan detects sensitive data in text and returns the exit code: 1 if any
+// detection matches failOn (or any detection when failOn is empty), else 0.
+// Every failOn type is scanned for, including opt-in types such as
+// social_handle and high_entropy; unknown types are an error.
+func runScan(ctx context.Context, engine *redaction.Engine, text string, failOn []string, w io.Writer) (int, error) {
+	types, err := scanTypes(engine, failOn)
+	if err != nil {
+		return 0, err
+	}
+
+	failTypes := make(map[redaction.Type]bool, len(types))
+	for _, rType := range types {
+		failTypes[rType] = true
+	}
+	if failTypes[redaction.TypeHighEntropy] {
+		engine.AddDetector(redaction.NewEntropyDetector(0, 0))
+	}
+
+	result, err := engine.RedactText(ctx, &redaction.Request{
+		Text:  text,
+		Mode:  redaction.ModeReplace,
+		Types: types,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	counts := make(map[redaction.Type]int)
+	for _, r := range result.Redactions {
+		if len(failTypes) == 0 || failTypes[r.Type] {
+			counts[r.Type]++
+		}
+	}
+
+	if len(counts) == 0 {
+		fmt.Fprintln(w, "✅ No sensitive data found")
+		return 0, nil
+	}
+
+	found := make([]redaction.Type, 0, len(counts))
+	for rType := range counts {
+		found = append(found, rType)
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i] < found[j] })
+
+	fmt.Fprintln(w, "❌ Sensitive data found:")
+	for _, rType := range found {
+		fmt.Fprintf(w, "  %s: %d\n", rType, counts[rType])
+	}
+
+	return 1, nil
+}
+
+// scanTypes parses --fail-on values, rejecting types the engine cannot
+// detect with an error listing those it can
+func scanTypes(engine *redaction.Engine, failOn []string) ([]redaction.Type, error) {
+	valid := map[redaction.Type]bool{redaction.TypeHighEntropy: true}
+	for _, rType := range engine.GetCapabilities().SupportedTypes {
+		valid[rType] = true
+	}
+
+	var types []redaction.Type
+	for _, name := range failOn {
+		rType := redaction.Type(strings.TrimSpace(name))
+		if !valid[rType] {
+			names := make([]string, 0, len(valid))
+			for known := range valid {
+				names = append(names, string(known))
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("unknown --fail-on type %q (valid types: %s)", rType, strings.Join(names, ", "))
+		}
+		types = append(types, rType)
+	}
+	return types, nil
+}
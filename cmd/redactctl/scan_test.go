@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/censgate/redact/pkg/redaction"
+)
+
+func TestRunScan(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		failOn   []string
+		expected int
+	}{
+		{"Input containing an SSN", "employee ssn: 123-45-6789", nil, 1},
+		{"Clean input", "nothing to see here", nil, 0},
+		{"Filtered to matching type", "employee ssn: 123-45-6789", []string{"email", "ssn"}, 1},
+		{"Filtered to other type", "employee ssn: 123-45-6789", []string{"email"}, 0},
+		{"Opt-in type listed in fail-on", "follow @jane_doe for updates", []string{"social_handle"}, 1},
+		{"High entropy listed in fail-on", "key=Zx9qL2vT8mR4wK7pN3sB6yH1", []string{"high_entropy"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var summary bytes.Buffer
+			code, err := runScan(context.Background(), redaction.NewEngine(), tt.text, tt.failOn, &summary)
+			if err != nil {
+				t.Fatalf("runScan failed: %v", err)
+			}
+			if code != tt.expected {
+				t.Errorf("Expected exit code %d, got %d (%s)", tt.expected, code, summary.String())
+			}
+			if tt.expected == 1 && strings.Contains(tt.text, "ssn") && !strings.Contains(summary.String(), "ssn: 1") {
+				t.Errorf("Expected summary to list the SSN, got %q", summary.String())
+			}
+		})
+	}
+}
+
+func TestRunScanUnknownFailOn(t *testing.T) {
+	var summary bytes.Buffer
+	_, err := runScan(context.Background(), redaction.NewEngine(), "employee ssn: 123-45-6789", []string{"ssm"}, &summary)
+	if err == nil {
+		t.Fatal("Expected an error for an unknown --fail-on type")
+	}
+	for _, want := range []string{`"ssm"`, "ssn", "email", "high_entropy"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %s in %q", want, err)
+		}
+	}
+}
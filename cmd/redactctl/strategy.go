@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/censgate/redact/pkg/redaction"
+	"github.com/censgate/redact/pkg/strategies"
+)
+
+// validateStrategy checks a --strategy value against the names of the
+// strategies in the engine's registry, which is the one it redacts with.
+// Empty means the replace mode and "default" each type's default strategy.
+func validateStrategy(registry strategies.StrategyRegistry, name string) error {
+	if name == "" || name == redaction.StrategyDefault {
+		return nil
+	}
+	if _, err := registry.GetStrategy(name); err == nil {
+		return nil
+	}
+
+	var names []string
+	for _, strategy := range registry.ListStrategies() {
+		names = append(names, strategy.GetName())
+	}
+	sort.Strings(names)
+
+	return fmt.Errorf("unknown strategy %q (valid strategies: %s)",
+		name, strings.Join(append(names, redaction.StrategyDefault), ", "))
+}
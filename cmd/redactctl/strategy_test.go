@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/censgate/redact/config"
+	"github.com/censgate/redact/pkg/redaction"
+	"github.com/censgate/redact/pkg/strategies"
+)
+
+// renamedStrategy registers a built-in strategy under another name, standing
+// in for a strategy only the configured registry knows
+type renamedStrategy struct {
+	*strategies.ConsistentHashStrategy
+	name string
+}
+
+func (s renamedStrategy) GetName() string { return s.name }
+
+func TestStrategyFlag(t *testing.T) {
+	t.Run("format_preserving keeps the SSN format", func(t *testing.T) {
+		if err := validateStrategy(redaction.NewEngine().StrategyRegistry(), "format_preserving"); err != nil {
+			t.Fatalf("Expected format_preserving to be valid, got %v", err)
+		}
+
+		result, err := redactText(context.Background(), redaction.NewEngine(), "format_preserving", "SSN: 123-45-6789")
+		if err != nil {
+			t.Fatalf("redactText failed: %v", err)
+		}
+		if !regexp.MustCompile(`^SSN: \d{3}-\d{2}-\d{4}$`).MatchString(result.RedactedText) {
+			t.Errorf("Expected a dashed SSN, got %q", result.RedactedText)
+		}
+		if strings.Contains(result.RedactedText, "123-45-6789") {
+			t.Errorf("Expected the SSN to be replaced, got %q", result.RedactedText)
+		}
+	})
+
+	t.Run("unknown strategies list the valid ones", func(t *testing.T) {
+		err := validateStrategy(redaction.NewEngine().StrategyRegistry(), "shuffle")
+		if err == nil {
+			t.Fatal("Expected an error for an unknown strategy")
+		}
+		for _, want := range []string{`"shuffle"`, "format_preserving", "semantic", "default"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected %s in %q", want, err)
+			}
+		}
+	})
+
+	t.Run("no strategy keeps the placeholders", func(t *testing.T) {
+		if err := validateStrategy(redaction.NewEngine().StrategyRegistry(), ""); err != nil {
+			t.Fatalf("Expected an empty strategy to be valid, got %v", err)
+		}
+		result, err := redactText(context.Background(), redaction.NewEngine(), "", "SSN: 123-45-6789")
+		if err != nil {
+			t.Fatalf("redactText failed: %v", err)
+		}
+		if result.RedactedText != "SSN: [SSN_REDACTED]" {
+			t.Errorf("Expected the placeholder, got %q", result.RedactedText)
+		}
+	})
+	t.Run("the engine's registry decides what is valid", func(t *testing.T) {
+		cfg := &config.Config{}
+		cfg.Redaction.Strategies.HashSalt = "pepper"
+		engine, err := config.NewEngine(cfg)
+		if err != nil {
+			t.Fatalf("config.NewEngine failed: %v", err)
+		}
+		registry, ok := engine.StrategyRegistry().(*strategies.DefaultStrategyRegistry)
+		if !ok {
+			t.Fatalf("Expected a DefaultStrategyRegistry, got %T", engine.StrategyRegistry())
+		}
+		if err := registry.Register(renamedStrategy{strategies.NewConsistentHashStrategyWithSalt("pepper"), "pepper_hash"}); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+
+		if err := validateStrategy(redaction.NewEngine().StrategyRegistry(), "pepper_hash"); err == nil {
+			t.Error("Expected the built-in registry to reject pepper_hash")
+		}
+		if err := validateStrategy(engine.StrategyRegistry(), "pepper_hash"); err != nil {
+			t.Fatalf("Expected the engine's registry to accept pepper_hash, got %v", err)
+		}
+
+		result, err := redactText(context.Background(), engine, "pepper_hash", "SSN: 123-45-6789")
+		if err != nil {
+			t.Fatalf("redactText failed: %v", err)
+		}
+		want, err := redactText(context.Background(), engine, "consistent_hash", "SSN: 123-45-6789")
+		if err != nil {
+			t.Fatalf("redactText failed: %v", err)
+		}
+		if strings.Contains(result.RedactedText, "123-45-6789") || result.RedactedText != want.RedactedText {
+			t.Errorf("Expected the configured salt's hash %q, got %q", want.RedactedText, result.RedactedText)
+		}
+	})
+}
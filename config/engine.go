@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/censgate/redact/pkg/redaction"
+	"github.com/censgate/redact/pkg/strategies"
+)
+
+// NewStrategyRegistry builds a strategy registry with the configured fallback
+// and per-type defaults applied on top of the built-in mappings
+func (c StrategiesConfig) NewStrategyRegistry() (*strategies.DefaultStrategyRegistry, error) {
+	registry := strategies.NewDefaultStrategyRegistry()
+
+	if c.HashSalt != "" {
+		if err := registry.Register(strategies.NewConsistentHashStrategyWithSalt(c.HashSalt)); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Default != "" {
+		if err := registry.SetFallbackStrategy(c.Default); err != nil {
+			return nil, fmt.Errorf("invalid default strategy: %w", err)
+		}
+	}
+
+	for redactionType, strategyName := range c.Types {
+		if err := registry.SetDefaultStrategy(redactionType, strategyName); err != nil {
+			return nil, fmt.Errorf("invalid strategy for type %s: %w", redactionType, err)
+		}
+	}
+
+	return registry, nil
+}
+
+// NewEngine creates a redaction engine with the configured strategy registry
+// attached, so requests using redaction.StrategyDefault follow the config.
+// The hash salt and encryption key, when set, enable the hash and encrypt modes.
+func NewEngine(cfg *Config) (*redaction.Engine, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+
+	registry, err := cfg.Redaction.Strategies.NewStrategyRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	engine := redaction.NewEngine()
+	engine.SetStrategyRegistry(registry)
+
+	if salt := cfg.Redaction.Strategies.HashSalt; salt != "" {
+		engine.SetHashSalt(salt)
+	}
+	if key := cfg.Redaction.Strategies.SignatureKey; key != "" {
+		if err := engine.SetSignatureKey([]byte(key)); err != nil {
+			return nil, err
+		}
+	}
+	if key := cfg.Encryption.Key; key != "" {
+		if err := engine.SetEncryptionKey([]byte(key)); err != nil {
+			return nil, err
+		}
+	}
+
+	return engine, nil
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/censgate/redact/pkg/redaction"
+	"github.com/censgate/redact/pkg/strategies"
+)
+
+func TestStrategiesConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	yaml := `redaction:
+  strategies:
+    default: "consistent_hash"
+    types:
+      ssn: "consistent_hash"
+      email: "format_preserving"
+`
+	if err := os.WriteFile(path, []byte(yaml), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Redaction.Strategies.Types["ssn"] != "consistent_hash" {
+		t.Fatalf("Expected ssn mapping to be loaded, got %v", cfg.Redaction.Strategies.Types)
+	}
+
+	engine, err := NewEngine(cfg)
+	if err != nil {
+		t.Fatalf("NewEngine failed: %v", err)
+	}
+
+	result, err := engine.RedactText(context.Background(), &redaction.Request{
+		Text:     "SSN 123-45-6789, mail john@example.com",
+		Mode:     redaction.ModeReplace,
+		Strategy: redaction.StrategyDefault,
+	})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+
+	for _, r := range result.Redactions {
+		switch r.Type {
+		case redaction.TypeSSN:
+			if !regexp.MustCompile(`^\*\*\*-\*\*-[0-9a-f]{4}$`).MatchString(r.Replacement) {
+				t.Errorf("Expected consistent hash SSN, got %q", r.Replacement)
+			}
+		case redaction.TypeEmail:
+			if !regexp.MustCompile(`^[a-z]+@[a-z]+\.[a-z]+$`).MatchString(r.Replacement) || r.Replacement == r.Original {
+				t.Errorf("Expected format preserving email, got %q", r.Replacement)
+			}
+		}
+	}
+
+	t.Run("Unknown strategy is rejected", func(t *testing.T) {
+		bad := StrategiesConfig{Types: map[string]string{"ssn": "no_such_strategy"}}
+		if _, err := bad.NewStrategyRegistry(); err == nil {
+			t.Error("Expected an error for an unknown strategy")
+		}
+	})
+}
+
+func TestSecretsFromEnvironment(t *testing.T) {
+	key := "0123456789abcdef0123456789abcdef"
+	t.Setenv("REDACT_ENCRYPTION_KEY", key)
+	t.Setenv("REDACT_STRATEGIES_HASH_SALT", "pepper")
+	t.Setenv("REDACT_STRATEGIES_SIGNATURE_KEY", "linkage-key")
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("logging:\n  level: info\n"), 0600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if cfg.Encryption.Key != key {
+		t.Errorf("Expected encryption key from environment, got %q", cfg.Encryption.Key)
+	}
+	if cfg.Redaction.Strategies.HashSalt != "pepper" {
+		t.Errorf("Expected hash salt from environment, got %q", cfg.Redaction.Strategies.HashSalt)
+	}
+
+	registry, err := cfg.Redaction.Strategies.NewStrategyRegistry()
+	if err != nil {
+		t.Fatalf("NewStrategyRegistry failed: %v", err)
+	}
+	hashStrategy, err := registry.GetStrategy("consistent_hash")
+	if err != nil {
+		t.Fatalf("GetStrategy failed: %v", err)
+	}
+	if salted, ok := hashStrategy.(*strategies.ConsistentHashStrategy); !ok || salted.GetSalt() != "pepper" {
+		t.Errorf("Expected consistent hash strategy to use the configured salt")
+	}
+
+	engine, err := NewEngine(cfg)
+	if err != nil {
+		t.Fatalf("NewEngine failed: %v", err)
+	}
+	result, err := engine.RedactText(context.Background(), &redaction.Request{
+		Text: "SSN 123-45-6789",
+		Mode: redaction.ModeEncrypt,
+	})
+	if err != nil {
+		t.Fatalf("Encrypt mode failed with a configured key: %v", err)
+	}
+	decrypted, err := engine.DecryptValue(result.Redactions[0].Replacement)
+	if err != nil || decrypted != "123-45-6789" {
+		t.Errorf("Expected the configured key to decrypt the SSN, got %q (%v)", decrypted, err)
+	}
+
+	if _, err := engine.RedactText(context.Background(), &redaction.Request{
+		Text: "SSN 123-45-6789",
+		Mode: redaction.ModeSignature,
+	}); err != nil {
+		t.Errorf("Signature mode failed with a configured key: %v", err)
+	}
+
+	t.Run("Invalid key length", func(t *testing.T) {
+		t.Setenv("REDACT_ENCRYPTION_KEY", "too-short")
+		if _, err := LoadConfig(path); err == nil {
+			t.Error("Expected an error for an encryption key of invalid length")
+		}
+	})
+}
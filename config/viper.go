@@ -19,8 +19,9 @@ type Config struct {
 
 // RedactionConfig holds configuration for redaction operations.
 type RedactionConfig struct {
-	Engine  EngineConfig  `mapstructure:"engine"`
-	Context ContextConfig `mapstructure:"context"`
+	Engine     EngineConfig     `mapstructure:"engine"`
+	Context    ContextConfig    `mapstructure:"context"`
+	Strategies StrategiesConfig `mapstructure:"strategies"`
 }
 
 // EngineConfig holds configuration for the redaction engine.
@@ -37,8 +38,19 @@ type ContextConfig struct {
 	Domains         []string `mapstructure:"domains"`
 }
 
+// StrategiesConfig holds the default replacement strategy for each type.
+type StrategiesConfig struct {
+	Default  string            `mapstructure:"default"`   // Strategy for types without a mapping
+	Types    map[string]string `mapstructure:"types"`     // Redaction type to strategy name
+	HashSalt string            `mapstructure:"hash_salt"` // Secret salt for hashing, from REDACT_STRATEGIES_HASH_SALT
+
+	// SignatureKey keys signature mode, from REDACT_STRATEGIES_SIGNATURE_KEY
+	SignatureKey string `mapstructure:"signature_key"`
+}
+
 // EncryptionConfig holds configuration for encryption operations.
 type EncryptionConfig struct {
+	Key                 string        `mapstructure:"key"` // AES key, from REDACT_ENCRYPTION_KEY
 	KeyRotationInterval time.Duration `mapstructure:"key_rotation_interval"`
 	PBKDF2Iterations    int           `mapstructure:"pbkdf2_iterations"`
 	KeyVersion          int           `mapstructure:"key_version"`
@@ -79,6 +91,9 @@ func LoadConfig(configFile string) (*Config, error) {
 
 	// Set defaults
 	setDefaults(v)
+	if err := bindSecrets(v); err != nil {
+		return nil, err
+	}
 
 	// Read config file
 	if err := v.ReadInConfig(); err != nil {
@@ -94,14 +109,45 @@ func LoadConfig(configFile string) (*Config, error) {
 		return nil, fmt.Errorf("error unmarshaling config: %w", err)
 	}
 
+	if err := config.validateSecrets(); err != nil {
+		return nil, err
+	}
+
 	return &config, nil
 }
 
+// bindSecrets binds secret settings to their environment variables. Secrets
+// have no defaults and should not be committed to config files.
+func bindSecrets(v *viper.Viper) error {
+	if err := v.BindEnv("encryption.key", "REDACT_ENCRYPTION_KEY"); err != nil {
+		return fmt.Errorf("error binding encryption key: %w", err)
+	}
+	if err := v.BindEnv("redaction.strategies.hash_salt", "REDACT_STRATEGIES_HASH_SALT"); err != nil {
+		return fmt.Errorf("error binding hash salt: %w", err)
+	}
+	if err := v.BindEnv("redaction.strategies.signature_key", "REDACT_STRATEGIES_SIGNATURE_KEY"); err != nil {
+		return fmt.Errorf("error binding signature key: %w", err)
+	}
+	return nil
+}
+
+// validateSecrets checks the secrets that were provided are usable
+func (c *Config) validateSecrets() error {
+	if key := c.Encryption.Key; key != "" {
+		switch len(key) {
+		case 16, 24, 32:
+		default:
+			return fmt.Errorf("encryption key must be 16, 24 or 32 bytes for AES, got %d", len(key))
+		}
+	}
+	return nil
+}
+
 // setDefaults sets default configuration values
 func setDefaults(v *viper.Viper) {
 	// Redaction engine defaults
 	v.SetDefault("redaction.engine.enabled_types", []string{
-		"email", "phone", "ssn", "credit_card", "name", "address",
+		"email", "phone", "ssn", "itin", "credit_card", "name", "address",
 		"date_time", "link", "zip_code", "po_box", "btc_address",
 		"md5_hex", "sha1_hex", "sha256_hex", "guid", "isbn", "mac_address", "iban", "git_repo",
 	})
@@ -113,6 +159,9 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("redaction.context.analysis_enabled", true)
 	v.SetDefault("redaction.context.domains", []string{"medical", "financial", "legal", "general"})
 
+	// Strategy defaults
+	v.SetDefault("redaction.strategies.default", "semantic")
+
 	// Encryption defaults
 	v.SetDefault("encryption.key_rotation_interval", "720h")
 	v.SetDefault("encryption.pbkdf2_iterations", 10000)
@@ -147,6 +196,9 @@ func GetViperInstance(configFile string) (*viper.Viper, error) {
 	v.AutomaticEnv()
 
 	setDefaults(v)
+	if err := bindSecrets(v); err != nil {
+		return nil, err
+	}
 
 	if err := v.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
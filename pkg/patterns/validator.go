@@ -83,8 +83,43 @@ func NewPatternValidator(strictMode bool) *PatternValidator {
 	}
 }
 
-// ValidateLibrary validates an entire pattern library
-func (v *PatternValidator) ValidateLibrary(library *PatternLibrary) *ValidationResult {
+// maxRegexLength caps the source length of library patterns so a
+// pathological pattern cannot exhaust memory while it compiles
+const maxRegexLength = 16 * 1024
+
+// compileRegex compiles a library pattern, returning an error rather than
+// panicking however malformed or large the pattern is
+func compileRegex(regex string) (compiled *regexp.Regexp, err error) {
+	if len(regex) > maxRegexLength {
+		return nil, fmt.Errorf("%d bytes exceeds the %d byte limit", len(regex), maxRegexLength)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			compiled, err = nil, fmt.Errorf("%v", r)
+		}
+	}()
+
+	return regexp.Compile(regex)
+}
+
+// ValidateLibrary validates an entire pattern library. A panic while
+// validating is reported as a LIBRARY_PANIC error rather than propagated.
+func (v *PatternValidator) ValidateLibrary(library *PatternLibrary) (validation *ValidationResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			validation = &ValidationResult{
+				Valid: false,
+				Errors: []ValidationError{{
+					Field:    "library",
+					Message:  fmt.Sprintf("Pattern library could not be validated: %v", r),
+					Code:     "LIBRARY_PANIC",
+					Severity: "error",
+				}},
+			}
+		}
+	}()
+
 	result := &ValidationResult{
 		Valid:      true,
 		Errors:     []ValidationError{},
@@ -205,7 +240,7 @@ func (v *PatternValidator) ValidatePattern(pattern *Pattern) *ValidationResult {
 
 	// Validate regex syntax
 	if pattern.Regex != "" {
-		if _, err := regexp.Compile(pattern.Regex); err != nil {
+		if _, err := compileRegex(pattern.Regex); err != nil {
 			result.Errors = append(result.Errors, ValidationError{
 				PatternID: pattern.ID,
 				Field:     "regex",
@@ -231,7 +266,7 @@ func (v *PatternValidator) ValidatePattern(pattern *Pattern) *ValidationResult {
 
 	// Validate examples against regex
 	if pattern.Regex != "" && len(pattern.Examples) > 0 {
-		if regex, err := regexp.Compile(pattern.Regex); err == nil {
+		if regex, err := compileRegex(pattern.Regex); err == nil {
 			for i, example := range pattern.Examples {
 				if !regex.MatchString(example) {
 					result.Warnings = append(result.Warnings, ValidationWarning{
@@ -0,0 +1,27 @@
+package patterns
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePathologicalPatterns(t *testing.T) {
+	validator := NewPatternValidator(false)
+
+	result := validator.ValidatePattern(&Pattern{
+		ID:         "huge",
+		Name:       "Huge",
+		Category:   "pii",
+		Regex:      strings.Repeat("(a|b)", 5000),
+		Confidence: 0.9,
+	})
+	if result.Valid || len(result.Errors) != 1 || result.Errors[0].Code != "INVALID_REGEX" {
+		t.Errorf("Expected one INVALID_REGEX error, got %+v", result.Errors)
+	}
+
+	// Panics while validating a library are reported, not propagated
+	result = validator.ValidateLibrary(nil)
+	if result.Valid || len(result.Errors) != 1 || result.Errors[0].Code != "LIBRARY_PANIC" {
+		t.Errorf("Expected one LIBRARY_PANIC error, got %+v", result.Errors)
+	}
+}
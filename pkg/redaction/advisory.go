@@ -0,0 +1,76 @@
+package redaction
+
+import (
+	"context"
+	"regexp"
+	"sort"
+)
+
+// advisoriesOption, when true, makes RedactText report risky-looking tokens
+// that no detector matched as Result.Advisories, without redacting them, so
+// gaps in pattern coverage can be found
+const advisoriesOption = "advisories"
+
+// Advisory reasons
+const (
+	AdvisoryDigitRun    = "digit_run"
+	AdvisoryHighEntropy = "high_entropy"
+)
+
+// Advisory is an unredacted token that looks sensitive, such as a long digit
+// run no pattern recognised
+type Advisory struct {
+	Reason   string `json:"reason"`
+	Start    int    `json:"start"`
+	End      int    `json:"end"`
+	Original string `json:"original"`
+}
+
+// digitRunPattern matches runs of digits long enough to be identifiers
+var digitRunPattern = regexp.MustCompile(`\d{12,}`)
+
+// advisoryEntropy flags high-entropy tokens with the default threshold,
+// minimum length and allowlist
+var advisoryEntropy = NewEntropyDetector(0, 0)
+
+// findAdvisories returns the long digit runs and high-entropy tokens of text
+// that overlap none of redactions, in document order
+func findAdvisories(text string, redactions []Redaction) []Advisory {
+	var advisories []Advisory
+	covered := func(start, end int) bool {
+		for _, redaction := range redactions {
+			if start < redaction.End && redaction.Start < end {
+				return true
+			}
+		}
+		for _, advisory := range advisories {
+			if start < advisory.End && advisory.Start < end {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, match := range digitRunPattern.FindAllStringIndex(text, -1) {
+		if !covered(match[0], match[1]) {
+			advisories = append(advisories, Advisory{
+				Reason: AdvisoryDigitRun, Start: match[0], End: match[1], Original: text[match[0]:match[1]],
+			})
+		}
+	}
+
+	// Detect never fails
+	tokens, _ := advisoryEntropy.Detect(context.Background(), text)
+	for _, token := range tokens {
+		if !covered(token.Start, token.End) {
+			advisories = append(advisories, Advisory{
+				Reason: AdvisoryHighEntropy, Start: token.Start, End: token.End, Original: text[token.Start:token.End],
+			})
+		}
+	}
+
+	sort.SliceStable(advisories, func(i, j int) bool {
+		return advisories[i].Start < advisories[j].Start
+	})
+	return advisories
+}
@@ -0,0 +1,48 @@
+package redaction
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestAdvisories(t *testing.T) {
+	engine := NewEngine()
+	text := "Ref 948372615093847 from jane@example.com, key Zx9Qw2Er7Ty4Ui1Op6As3Df8Gh5Jk"
+
+	result, err := engine.RedactText(context.Background(), &Request{
+		Text:    text,
+		Mode:    ModeReplace,
+		Options: map[string]interface{}{"advisories": true},
+	})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+
+	if len(result.Redactions) != 1 || result.Redactions[0].Type != TypeEmail {
+		t.Fatalf("Expected only the email to be redacted, got %v", getRedactionTypes(result.Redactions))
+	}
+	if !strings.Contains(result.RedactedText, "948372615093847") {
+		t.Errorf("Expected the digit run to be left in the text, got %s", result.RedactedText)
+	}
+
+	if len(result.Advisories) != 2 {
+		t.Fatalf("Expected two advisories, got %+v", result.Advisories)
+	}
+	if advisory := result.Advisories[0]; advisory.Reason != AdvisoryDigitRun || advisory.Original != "948372615093847" ||
+		text[advisory.Start:advisory.End] != advisory.Original {
+		t.Errorf("Expected a digit run advisory, got %+v", advisory)
+	}
+	if advisory := result.Advisories[1]; advisory.Reason != AdvisoryHighEntropy || advisory.Original != "Zx9Qw2Er7Ty4Ui1Op6As3Df8Gh5Jk" {
+		t.Errorf("Expected a high-entropy advisory, got %+v", advisory)
+	}
+
+	// Advisories are opt-in
+	result, err = engine.RedactText(context.Background(), &Request{Text: text, Mode: ModeReplace})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+	if len(result.Advisories) != 0 {
+		t.Errorf("Expected no advisories without the option, got %+v", result.Advisories)
+	}
+}
@@ -0,0 +1,100 @@
+package redaction
+
+// maxRedactionsOption caps the number of redactions RedactText applies, and
+// maxOutputGrowthOption caps how many bytes longer than the input the
+// redacted text may grow. Once either budget is spent RedactText stops: the
+// redacted text ends where the first redaction that did not fit starts, and
+// the result is marked Truncated. Overlap resolution and replacement stop as
+// soon as the budget is known to be spent, so no work is done, and no token
+// is kept, for the redactions past the cut.
+const (
+	maxRedactionsOption   = "max_redactions"
+	maxOutputGrowthOption = "max_output_growth"
+)
+
+// redactionBudget tracks a request's budget while its redactions are
+// replaced. A nil budget is unlimited.
+type redactionBudget struct {
+	maxRedactions int
+	maxGrowth     int
+	growthCapped  bool
+	growth        int
+}
+
+// newRedactionBudget returns the budget of a request, or nil when it has none
+func newRedactionBudget(request *Request) *redactionBudget {
+	maxRedactions := optionInt(request.Options, maxRedactionsOption)
+	_, growthCapped := request.Options[maxOutputGrowthOption]
+	if maxRedactions <= 0 && !growthCapped {
+		return nil
+	}
+	return &redactionBudget{
+		maxRedactions: maxRedactions,
+		maxGrowth:     optionInt(request.Options, maxOutputGrowthOption),
+		growthCapped:  growthCapped,
+	}
+}
+
+// allows reports whether the redaction at index i fits the redaction count
+func (b *redactionBudget) allows(i int) bool {
+	return b == nil || b.maxRedactions <= 0 || i < b.maxRedactions
+}
+
+// spend adds the growth of a replaced redaction, reporting whether the
+// output still fits
+func (b *redactionBudget) spend(redaction Redaction) bool {
+	if b == nil {
+		return true
+	}
+	b.growth += len(redaction.Replacement) - (redaction.End - redaction.Start)
+	return !b.growthCapped || b.growth <= b.maxGrowth
+}
+
+// resolveLimit returns how many winners overlap resolution must settle for a
+// request, or 0 to resolve every match: one past max_redactions, as the text
+// is cut where that redaction starts. Merging adjacent redactions changes
+// the count after resolution, so merging requests resolve everything.
+func resolveLimit(request *Request) int {
+	maxRedactions := optionInt(request.Options, maxRedactionsOption)
+	if maxRedactions <= 0 || mergeGap(request.Options) > 0 {
+		return 0
+	}
+	return maxRedactions + 1
+}
+
+// applyBudget returns the leading redactions that fit the request's budget
+// and the offset the text is cut at, which is length when every redaction
+// fits. redactions must be sorted by start position, ascending.
+func applyBudget(length int, redactions []Redaction, request *Request) ([]Redaction, int, bool) {
+	budget := newRedactionBudget(request)
+	for i, redaction := range redactions {
+		if !budget.allows(i) || !budget.spend(redaction) {
+			return redactions[:i], redaction.Start, true
+		}
+	}
+	return redactions, length, false
+}
+
+// discardTokens removes the tokens minted for redactions that were cut by
+// the budget, so the originals past the cut are not kept
+func (re *Engine) discardTokens(redactions []Redaction) {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	for _, redaction := range redactions {
+		if redaction.Token != "" {
+			delete(re.tokens, redaction.Token)
+		}
+	}
+}
+
+// candidatesBefore returns the candidates that start before the cut
+func candidatesBefore(candidates []Redaction, cut int) []Redaction {
+	kept := candidates[:0]
+	for _, candidate := range candidates {
+		if candidate.Start < cut {
+			kept = append(kept, candidate)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,112 @@
+package redaction
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRedactionBudget(t *testing.T) {
+	engine := NewEngine()
+	text := strings.Repeat("mail a@b.co now ", 20000)
+
+	t.Run("max redactions", func(t *testing.T) {
+		replaced := 0
+		result, err := engine.RedactText(context.Background(), &Request{
+			Text:    text,
+			Mode:    ModeReplace,
+			Options: map[string]interface{}{"max_redactions": 100},
+			ReplacerFunc: func(redaction Redaction) string {
+				replaced++
+				return redaction.Replacement
+			},
+		})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		if replaced != 100 {
+			t.Errorf("Expected replacement to stop after 100 redactions, made %d", replaced)
+		}
+
+		if !result.Truncated {
+			t.Error("Expected the result to be truncated")
+		}
+		if len(result.Redactions) != 100 {
+			t.Errorf("Expected 100 redactions, got %d", len(result.Redactions))
+		}
+		if strings.Contains(result.RedactedText, "a@b.co") {
+			t.Error("Expected no unredacted email in the truncated output")
+		}
+		if want := strings.Repeat("mail [EMAIL_REDACTED] now ", 100) + "mail "; result.RedactedText != want {
+			t.Errorf("Expected the output to stop at the 101st email, got %d bytes", len(result.RedactedText))
+		}
+	})
+
+	t.Run("max output growth", func(t *testing.T) {
+		// Each placeholder is 10 bytes longer than the email it replaces
+		result, err := engine.RedactText(context.Background(), &Request{
+			Text:    "a@b.co, c@d.co, e@f.co",
+			Mode:    ModeReplace,
+			Options: map[string]interface{}{"max_output_growth": 25},
+		})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		if !result.Truncated || len(result.Redactions) != 2 {
+			t.Fatalf("Expected two redactions and truncation, got %d (truncated %v)", len(result.Redactions), result.Truncated)
+		}
+		if result.RedactedText != "[EMAIL_REDACTED], [EMAIL_REDACTED], " {
+			t.Errorf("Unexpected output: %q", result.RedactedText)
+		}
+	})
+
+	t.Run("no tokens past the cut", func(t *testing.T) {
+		engine := NewEngine()
+		// Each in-text token is 18 bytes longer than the email it replaces
+		result, err := engine.RedactText(context.Background(), &Request{
+			Text:    "a@b.co, c@d.co, e@f.co",
+			Mode:    ModeTokenize,
+			Options: map[string]interface{}{"max_output_growth": 40},
+		})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		if !result.Truncated || len(result.Redactions) != 2 {
+			t.Fatalf("Expected two redactions and truncation, got %d (truncated %v)", len(result.Redactions), result.Truncated)
+		}
+		if stored := len(engine.tokens); stored != 2 {
+			t.Errorf("Expected tokens for the 2 kept redactions only, got %d", stored)
+		}
+	})
+
+	t.Run("candidates stop at the cut", func(t *testing.T) {
+		result, err := engine.RedactText(context.Background(), &Request{
+			Text:    strings.Repeat("mail a@b.co now ", 50),
+			Mode:    ModeReplace,
+			Options: map[string]interface{}{"max_redactions": 3, "overlap_policy": "annotate"},
+		})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		cut := len(strings.Repeat("mail a@b.co now ", 3)) + len("mail ")
+		for _, candidate := range result.Candidates {
+			if candidate.Start >= cut {
+				t.Fatalf("Expected no candidates past the cut at %d, got %+v", cut, candidate)
+			}
+		}
+	})
+
+	t.Run("within budget", func(t *testing.T) {
+		result, err := engine.RedactText(context.Background(), &Request{
+			Text:    "a@b.co, c@d.co",
+			Mode:    ModeReplace,
+			Options: map[string]interface{}{"max_redactions": 2, "max_output_growth": 20},
+		})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		if result.Truncated || result.RedactedText != "[EMAIL_REDACTED], [EMAIL_REDACTED]" {
+			t.Errorf("Expected an untruncated result, got %q (truncated %v)", result.RedactedText, result.Truncated)
+		}
+	})
+}
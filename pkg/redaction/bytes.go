@@ -0,0 +1,94 @@
+package redaction
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// bytesSource is a matchSource over a byte slice. Only matched spans and their
+// context are converted to strings.
+type bytesSource []byte
+
+func (b bytesSource) findAll(pattern *regexp.Regexp) [][]int {
+	return pattern.FindAllIndex(b, -1)
+}
+
+func (b bytesSource) findAllSubmatch(pattern *regexp.Regexp) [][]int {
+	return pattern.FindAllSubmatchIndex(b, -1)
+}
+
+func (b bytesSource) slice(start, end int) string { return string(b[start:end]) }
+
+func (b bytesSource) length() int { return len(b) }
+
+// RedactBytes redacts a byte slice without first converting it to a string.
+// Detection, custom patterns, overlap resolution, modes, budgets, hooks and
+// the other request options behave exactly as in RedactText, and the
+// returned offsets refer to data. request.Text is ignored. To avoid copying
+// the input, Result.OriginalText and Result.RedactedText are left empty unless
+// the request is reversible, in which case OriginalText is populated so the
+// whole-document token can be restored. Advisories and collapse_whitespace
+// work on a string copy of data, so they cost one copy when requested.
+func (re *Engine) RedactBytes(ctx context.Context, data []byte, request *Request) (*Result, []byte, error) {
+	if request == nil {
+		return nil, nil, fmt.Errorf("redaction request cannot be nil")
+	}
+	re.runBeforeHooks(request)
+
+	if err := re.checkRequest(ctx, request, len(data)); err != nil {
+		return nil, nil, err
+	}
+
+	src := bytesSource(data)
+	redactions, candidates, errs := re.findRedactions(ctx, src, request, findOptions{limit: resolveLimit(request)})
+	result, cut, errs := re.settleRedactions(ctx, src, request, redactions, candidates, errs)
+
+	var output []byte
+	if optionBool(request.Options, collapseWhitespaceOption) {
+		output = []byte(applyRedactionsCollapsing(string(data[:cut]), result.Redactions))
+	} else {
+		output = applyRedactionsToBytes(data[:cut], result.Redactions)
+	}
+	output, result.EmptyOutputReplaced = fillEmptyBytes(output, result.Redactions, request)
+	if optionBool(request.Options, advisoriesOption) {
+		result.Advisories = findAdvisories(string(data[:cut]), result.Redactions)
+	}
+
+	if err := checkGrowth(request, len(data), len(output)); err != nil {
+		return nil, nil, err
+	}
+	setGrowth(result, len(data), len(output))
+	setRuneOffsets(data, result.Redactions, result.Candidates)
+
+	// The whole-document token restores from OriginalText
+	if request.Reversible && len(result.Redactions) > 0 {
+		result.OriginalText = string(data)
+	}
+	result = re.finishRedactions(result, request)
+
+	re.runAfterHooks(result)
+	return result, output, re.detectorFailure(errs)
+}
+
+// applyRedactionsToBytes builds the redacted output in a single pass.
+// redactions must be non-overlapping and sorted by start position, ascending.
+func applyRedactionsToBytes(data []byte, redactions []Redaction) []byte {
+	size := len(data)
+	for _, redaction := range redactions {
+		size += len(redaction.Replacement) - (redaction.End - redaction.Start)
+	}
+
+	output := make([]byte, 0, size)
+	last := 0
+	for _, redaction := range redactions {
+		if redaction.Start < last || redaction.End > len(data) {
+			continue
+		}
+		output = append(output, data[last:redaction.Start]...)
+		output = append(output, redaction.Replacement...)
+		last = redaction.End
+	}
+
+	return append(output, data[last:]...)
+}
@@ -0,0 +1,151 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+const bytesTestText = "Contact john.doe@example.com or 555-123-4567. SSN: 123-45-6789, server 192.168.1.1"
+
+func TestRedactBytesMatchesRedactText(t *testing.T) {
+	engine := NewEngine()
+	request := &Request{Mode: ModeReplace}
+
+	textResult, err := engine.RedactText(context.Background(), &Request{Text: bytesTestText, Mode: ModeReplace})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+
+	bytesResult, output, err := engine.RedactBytes(context.Background(), []byte(bytesTestText), request)
+	if err != nil {
+		t.Fatalf("RedactBytes failed: %v", err)
+	}
+
+	if string(output) != textResult.RedactedText {
+		t.Errorf("Expected %q, got %q", textResult.RedactedText, string(output))
+	}
+
+	if len(bytesResult.Redactions) != len(textResult.Redactions) {
+		t.Fatalf("Expected %d redactions, got %d", len(textResult.Redactions), len(bytesResult.Redactions))
+	}
+	for i, redaction := range bytesResult.Redactions {
+		expected := textResult.Redactions[i]
+		if redaction.Type != expected.Type || redaction.Start != expected.Start || redaction.End != expected.End {
+			t.Errorf("Redaction %d mismatch: expected %s[%d:%d], got %s[%d:%d]", i,
+				expected.Type, expected.Start, expected.End, redaction.Type, redaction.Start, redaction.End)
+		}
+	}
+}
+
+func TestRedactBytesReversible(t *testing.T) {
+	engine := NewEngine()
+
+	result, _, err := engine.RedactBytes(context.Background(), []byte(bytesTestText), &Request{
+		Mode:       ModeReplace,
+		Reversible: true,
+	})
+	if err != nil {
+		t.Fatalf("RedactBytes failed: %v", err)
+	}
+
+	restored, err := engine.RestoreText(context.Background(), result.Token)
+	if err != nil {
+		t.Fatalf("RestoreText failed: %v", err)
+	}
+	if restored.OriginalText != bytesTestText {
+		t.Errorf("Expected restored text to match original, got %q", restored.OriginalText)
+	}
+}
+
+func TestRedactBytesRequestOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		request Request
+	}{
+		{"budget", bytesTestText, Request{Mode: ModeReplace, Options: map[string]interface{}{"max_redactions": 2}}},
+		{"empty placeholder", "john.doe@example.com", Request{Mode: ModeRemove, Options: map[string]interface{}{"empty_placeholder": true}}},
+		{"advisories", "Ref 948372615093847 from jane@example.com, key Zx9Qw2Er7Ty4Ui1Op6As3Df8Gh5Jk", Request{Mode: ModeReplace, Options: map[string]interface{}{"advisories": true}}},
+		{"mapping", bytesTestText, Request{Mode: ModeReplace, IncludeMapping: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewEngine()
+			textRequest := tt.request
+			textRequest.Text = tt.text
+			textResult, err := engine.RedactText(context.Background(), &textRequest)
+			if err != nil {
+				t.Fatalf("RedactText failed: %v", err)
+			}
+
+			bytesRequest := tt.request
+			result, output, err := engine.RedactBytes(context.Background(), []byte(tt.text), &bytesRequest)
+			if err != nil {
+				t.Fatalf("RedactBytes failed: %v", err)
+			}
+
+			if string(output) != textResult.RedactedText {
+				t.Errorf("Expected %q, got %q", textResult.RedactedText, output)
+			}
+			if result.Truncated != textResult.Truncated || result.EmptyOutputReplaced != textResult.EmptyOutputReplaced {
+				t.Errorf("Expected truncated %v and empty replaced %v, got %v and %v",
+					textResult.Truncated, textResult.EmptyOutputReplaced, result.Truncated, result.EmptyOutputReplaced)
+			}
+			if len(result.Advisories) != len(textResult.Advisories) || len(result.Mapping) != len(textResult.Mapping) {
+				t.Errorf("Expected %d advisories and %d mappings, got %d and %d",
+					len(textResult.Advisories), len(textResult.Mapping), len(result.Advisories), len(result.Mapping))
+			}
+			if result.GrowthRatio != textResult.GrowthRatio {
+				t.Errorf("Expected growth ratio %v, got %v", textResult.GrowthRatio, result.GrowthRatio)
+			}
+		})
+	}
+
+	t.Run("max growth ratio", func(t *testing.T) {
+		_, _, err := NewEngine().RedactBytes(context.Background(), []byte("a@b.co"), &Request{
+			Mode:    ModeReplace,
+			Options: map[string]interface{}{"max_growth_ratio": 2.0},
+		})
+		if !errors.Is(err, ErrOutputTooLarge) {
+			t.Errorf("Expected ErrOutputTooLarge, got %v", err)
+		}
+	})
+
+	t.Run("hooks", func(t *testing.T) {
+		engine := NewEngine()
+		before, after := 0, 0
+		engine.OnBeforeRedact(func(*Request) { before++ })
+		engine.OnAfterRedact(func(*Result) { after++ })
+
+		if _, _, err := engine.RedactBytes(context.Background(), []byte(bytesTestText), &Request{Mode: ModeReplace}); err != nil {
+			t.Fatalf("RedactBytes failed: %v", err)
+		}
+		if before != 1 || after != 1 {
+			t.Errorf("Expected each hook to run once, got %d and %d", before, after)
+		}
+	})
+}
+
+func BenchmarkRedactText(b *testing.B) {
+	engine := NewEngine()
+	text := strings.Repeat(bytesTestText+"\n", 50)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = engine.RedactText(context.Background(), &Request{Text: text, Mode: ModeReplace})
+	}
+}
+
+func BenchmarkRedactBytes(b *testing.B) {
+	engine := NewEngine()
+	data := []byte(strings.Repeat(bytesTestText+"\n", 50))
+	request := &Request{Mode: ModeReplace}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = engine.RedactBytes(context.Background(), data, request)
+	}
+}
@@ -0,0 +1,103 @@
+package redaction
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// highRiskScore is the risk score from which Classify recommends replacing
+// matches outright rather than tokenizing them
+const highRiskScore = 0.6
+
+// Classification summarises what Classify found in a text. It keeps the
+// detected spans, so RedactClassified can redact the same text without
+// running detection again.
+type Classification struct {
+	TypeCounts map[Type]int `json:"type_counts"`
+
+	// RiskScore is the highest overlap priority among the detected types
+	// scaled to [0, 1], so a national insurance number scores 1 and an IP
+	// address 0.4. A text with no matches scores 0.
+	RiskScore float64 `json:"risk_score"`
+
+	// RecommendedMode is ModeReplace for high-risk texts, ModeTokenize for
+	// texts with only lower-risk matches and empty when nothing was found
+	RecommendedMode Mode `json:"recommended_mode,omitempty"`
+
+	text       string
+	redactions []Redaction
+	errs       []error
+}
+
+// Classify detects the sensitive data in text with the default request
+// settings and summarises it without redacting. As with RedactText, a
+// failing detector is reported in the error alongside the classification of
+// the other matches.
+func (re *Engine) Classify(ctx context.Context, text string) (*Classification, error) {
+	request := &Request{Text: text, Mode: ModeReplace}
+	if err := re.checkRequest(ctx, request, len(request.Text)); err != nil {
+		return nil, err
+	}
+
+	redactions, _, errs := re.findRedactions(ctx, stringSource(text), request, findOptions{})
+	classification := &Classification{
+		TypeCounts: make(map[Type]int),
+		text:       text,
+		redactions: redactions,
+		errs:       errs,
+	}
+
+	highest := 0
+	for _, redaction := range redactions {
+		classification.TypeCounts[redaction.Type]++
+		highest = maxInt(highest, re.getTypePriority(redaction.Type))
+	}
+	classification.RiskScore = math.Min(float64(highest)/100, 1)
+
+	switch {
+	case len(redactions) == 0:
+	case classification.RiskScore >= highRiskScore:
+		classification.RecommendedMode = ModeReplace
+	default:
+		classification.RecommendedMode = ModeTokenize
+	}
+
+	return classification, re.detectorFailure(errs)
+}
+
+// RedactClassified redacts text using the spans found by Classify. overrides
+// supplies the mode, strategy and other replacement settings as in
+// RedactText; its Text is ignored and its detection settings, such as Types
+// and CustomPatterns, have no effect. Without overrides, or with an empty
+// mode, the classification's recommended mode is used.
+func (re *Engine) RedactClassified(ctx context.Context, text string, classification *Classification, overrides *Request) (*Result, error) {
+	if classification == nil {
+		return nil, fmt.Errorf("classification cannot be nil")
+	}
+	if text != classification.text {
+		return nil, fmt.Errorf("classification was made for a different text")
+	}
+
+	request := &Request{}
+	if overrides != nil {
+		copied := *overrides
+		request = &copied
+	}
+	request.Text = text
+	if request.Mode == "" {
+		request.Mode = classification.RecommendedMode
+	}
+
+	if err := re.checkRequest(ctx, request, len(request.Text)); err != nil {
+		return nil, err
+	}
+
+	redactions := append([]Redaction(nil), classification.redactions...)
+	errs := append([]error(nil), classification.errs...)
+	result, errs := re.redactFound(ctx, request, redactions, nil, errs)
+	if err := checkGrowth(request, len(result.OriginalText), len(result.RedactedText)); err != nil {
+		return nil, err
+	}
+	return re.finishResult(result, request), re.detectorFailure(errs)
+}
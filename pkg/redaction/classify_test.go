@@ -0,0 +1,86 @@
+package redaction
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestClassify(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+	text := "Email john@example.com or jane@example.com, NI AB123456C"
+
+	classification, err := engine.Classify(ctx, text)
+	if err != nil {
+		t.Fatalf("Classify failed: %v", err)
+	}
+	if classification.TypeCounts[TypeEmail] != 2 || classification.TypeCounts[TypeUKNationalInsurance] != 1 {
+		t.Errorf("Unexpected type counts: %v", classification.TypeCounts)
+	}
+	if classification.RiskScore != 1 || classification.RecommendedMode != ModeReplace {
+		t.Errorf("Expected a high-risk replace recommendation, got %v and %q",
+			classification.RiskScore, classification.RecommendedMode)
+	}
+
+	low, err := engine.Classify(ctx, "Server 192.168.1.1")
+	if err != nil {
+		t.Fatalf("Classify failed: %v", err)
+	}
+	if low.RiskScore != 0.4 || low.RecommendedMode != ModeTokenize {
+		t.Errorf("Expected a low-risk tokenize recommendation, got %v and %q", low.RiskScore, low.RecommendedMode)
+	}
+
+	none, err := engine.Classify(ctx, "nothing to see here")
+	if err != nil {
+		t.Fatalf("Classify failed: %v", err)
+	}
+	if none.RiskScore != 0 || none.RecommendedMode != "" || len(none.TypeCounts) != 0 {
+		t.Errorf("Expected an empty classification, got %+v", none)
+	}
+}
+
+func TestRedactClassifiedMatchesRedactText(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+	text := "Call 555-123-4567 or mail john@example.com, SSN 123-45-6789, card 4111 1111 1111 1111"
+
+	classification, err := engine.Classify(ctx, text)
+	if err != nil {
+		t.Fatalf("Classify failed: %v", err)
+	}
+
+	for _, mode := range []Mode{ModeReplace, ModeRemove} {
+		overrides := &Request{Mode: mode, Options: map[string]interface{}{"merge_adjacent": true}}
+		twoPhase, err := engine.RedactClassified(ctx, text, classification, overrides)
+		if err != nil {
+			t.Fatalf("RedactClassified failed: %v", err)
+		}
+
+		single, err := engine.RedactText(ctx, &Request{Text: text, Mode: mode, Options: overrides.Options})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+
+		if !reflect.DeepEqual(twoPhase.Redactions, single.Redactions) {
+			t.Errorf("%s: expected identical redactions,\ntwo-phase: %+v\nsingle:    %+v", mode, twoPhase.Redactions, single.Redactions)
+		}
+		if twoPhase.RedactedText != single.RedactedText {
+			t.Errorf("%s: expected %q, got %q", mode, single.RedactedText, twoPhase.RedactedText)
+		}
+	}
+
+	// The classification can be reused, and its recommended mode applies
+	// without overrides
+	result, err := engine.RedactClassified(ctx, text, classification, nil)
+	if err != nil {
+		t.Fatalf("RedactClassified failed: %v", err)
+	}
+	if len(result.Redactions) != len(classification.redactions) || result.Redactions[0].Replacement != "[PHONE_REDACTED]" {
+		t.Errorf("Expected the recommended replace mode, got %+v", result.Redactions)
+	}
+
+	if _, err := engine.RedactClassified(ctx, text+" more", classification, nil); err == nil {
+		t.Error("Expected an error for a different text")
+	}
+}
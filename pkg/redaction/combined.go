@@ -0,0 +1,137 @@
+package redaction
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// combinedScan is a single regexp joining the patterns of several types as
+// named alternatives, so one pass over the text finds matches of all of them
+type combinedScan struct {
+	pattern *regexp.Regexp
+	groups  []combinedGroup
+	types   map[Type]bool
+}
+
+// combinedGroup ties a capture group of the combined pattern to its type
+type combinedGroup struct {
+	index         int
+	redactionType Type
+}
+
+// SetCombinedScan enables or disables combined scanning. When enabled, the
+// patterns of types without validators, keywords or value groups are matched
+// in one pass with a single regexp; the remaining types are still scanned one
+// pattern at a time. A combined pass reports one match per position (the
+// longest, ties going to the higher priority type), so candidates that
+// overlap a match starting earlier are not seen by overlap resolution.
+// Go's regexp engine runs large alternations on its NFA matcher, so measure
+// with BenchmarkCombinedScan before enabling it for performance.
+func (re *Engine) SetCombinedScan(enabled bool) {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.combinedScanEnabled = enabled
+	re.combined = nil
+}
+
+// invalidateCombinedScan drops the cached combined pattern after the type
+// patterns or validators change. Callers must hold the engine lock.
+func (re *Engine) invalidateCombinedScan() {
+	re.combined = nil
+}
+
+// combinedScanner returns the combined pattern, building it on first use, or
+// nil when combined scanning is disabled
+func (re *Engine) combinedScanner() *combinedScan {
+	re.mutex.RLock()
+	enabled, scan := re.combinedScanEnabled, re.combined
+	re.mutex.RUnlock()
+
+	if !enabled || scan != nil {
+		return scan
+	}
+
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	if re.combined == nil {
+		re.combined = re.buildCombinedScan()
+	}
+	return re.combined
+}
+
+// buildCombinedScan joins every combinable pattern into one regexp. Callers
+// must hold the engine lock.
+func (re *Engine) buildCombinedScan() *combinedScan {
+	var types []Type
+	for redactionType := range re.patterns {
+		if re.combinable(redactionType) {
+			types = append(types, redactionType)
+		}
+	}
+
+	// Alternatives are tried in priority order so equal-length ties resolve
+	// the way overlap resolution would
+	sort.Slice(types, func(i, j int) bool {
+		pi, pj := re.getTypePriorityLocked(types[i]), re.getTypePriorityLocked(types[j])
+		if pi != pj {
+			return pi > pj
+		}
+		return types[i] < types[j]
+	})
+
+	alternatives := make([]string, len(types))
+	for i, redactionType := range types {
+		alternatives[i] = fmt.Sprintf("(?P<t%d>%s)", i, re.patterns[redactionType].String())
+	}
+
+	pattern, err := regexp.Compile(strings.Join(alternatives, "|"))
+	if err != nil {
+		return &combinedScan{types: map[Type]bool{}} // Scan every pattern separately
+	}
+	pattern.Longest()
+
+	scan := &combinedScan{pattern: pattern, types: make(map[Type]bool, len(types))}
+	for i, redactionType := range types {
+		scan.groups = append(scan.groups, combinedGroup{
+			index:         pattern.SubexpIndex(fmt.Sprintf("t%d", i)),
+			redactionType: redactionType,
+		})
+		scan.types[redactionType] = true
+	}
+	return scan
+}
+
+// combinable reports whether a type's matches can be found by the combined
+// pattern: every match of its pattern is a redaction at default, or
+// calibrated, confidence
+func (re *Engine) combinable(redactionType Type) bool {
+	_, hasValidator := re.validators[redactionType]
+	_, hasValueGroup := valueGroupTypes[redactionType]
+	return !hasValidator && !hasValueGroup && !contextDependentTypes[redactionType] && !optInTypes[redactionType]
+}
+
+// collectCombined finds the matches of the combined pattern in src
+func (re *Engine) collectCombined(src matchSource, scan *combinedScan, request *Request) []Redaction {
+	calibrate := re.confidenceFuncSnapshot()
+	if scan.pattern == nil || (calibrate == nil && defaultConfidence < request.MinConfidence) {
+		return nil
+	}
+
+	var redactions []Redaction
+	for _, match := range src.findAllSubmatch(scan.pattern) {
+		for _, group := range scan.groups {
+			if start, end, ok := targetSpan(match, group.index); ok {
+				confidence := re.calibratedConfidence(calibrate, src, group.redactionType, start, end, defaultConfidence)
+				if confidence >= request.MinConfidence {
+					redactions = append(redactions, re.patternRedaction(src, group.redactionType, start, end, confidence))
+				}
+				break
+			}
+		}
+	}
+	return redactions
+}
@@ -0,0 +1,77 @@
+package redaction
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// combinedTestDocument is a support-ticket style document mixing many types
+const combinedTestDocument = `Ticket #4821 opened 03/14/2024 at 09:15 by john.doe@example.com
+Callback: (555) 123-4567 ext. 12, alt 555.987.6543
+Customer SSN 123-45-6789, card 4111 1111 1111 1111, ITIN 912-70-1234
+Client IP 192.168.10.24, device 00:1A:2B:3C:4D:5E, session 123e4567-e89b-12d3-a456-426614174000
+Notes at https://support.example.com/tickets/4821 and hooks.slack.com/services/T000/B000/XXXX
+Ship to PO Box 1234, Springfield IL 62704; UK contact +44 20 7946 0958, NI AB123456C
+Checksum d41d8cd98f00b204e9800998ecf8427e, repo git@github.com:censgate/redact.git
+`
+
+func TestCombinedScanMatchesPerPattern(t *testing.T) {
+	ctx := context.Background()
+	request := &Request{Text: combinedTestDocument, Mode: ModeReplace}
+
+	perPattern, err := NewEngine().RedactText(ctx, request)
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+
+	engine := NewEngine()
+	engine.SetCombinedScan(true)
+	combined, err := engine.RedactText(ctx, request)
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+
+	if engine.combinedScanner() == nil || len(engine.combinedScanner().types) == 0 {
+		t.Fatal("Expected combined scanning to cover some types")
+	}
+	if !reflect.DeepEqual(combined.Redactions, perPattern.Redactions) {
+		t.Errorf("Combined scan differs from per-pattern scan:\ncombined:    %+v\nper-pattern: %+v",
+			combined.Redactions, perPattern.Redactions)
+	}
+	if combined.RedactedText != perPattern.RedactedText {
+		t.Errorf("Expected identical redacted text, got %q and %q", combined.RedactedText, perPattern.RedactedText)
+	}
+
+	// Pattern changes rebuild the combined pattern
+	if err := engine.AddCustomPattern("ticket_id", `Ticket #\d+`); err != nil {
+		t.Fatalf("AddCustomPattern failed: %v", err)
+	}
+	result, err := engine.RedactText(ctx, request)
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+	if !strings.Contains(result.RedactedText, "[REDACTED] opened") {
+		t.Errorf("Expected the new pattern to be scanned, got %q", result.RedactedText)
+	}
+}
+
+func BenchmarkPerPatternScan(b *testing.B) {
+	benchmarkScan(b, false)
+}
+
+func BenchmarkCombinedScan(b *testing.B) {
+	benchmarkScan(b, true)
+}
+
+func benchmarkScan(b *testing.B, combined bool) {
+	engine := NewEngine()
+	engine.SetCombinedScan(combined)
+	request := &Request{Text: strings.Repeat(combinedTestDocument, 20), Mode: ModeReplace}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = engine.RedactText(context.Background(), request)
+	}
+}
@@ -0,0 +1,74 @@
+package redaction
+
+import "sort"
+
+// Diff reports how a set of redactions differs from the expected findings,
+// matching redactions by their Start and End offsets
+type Diff struct {
+	Missing    []Redaction `json:"missing,omitempty"`    // Expected spans nothing redacted
+	Extra      []Redaction `json:"extra,omitempty"`      // Redacted spans nothing was expected at
+	Mismatched []Mismatch  `json:"mismatched,omitempty"` // Spans redacted as the wrong type
+}
+
+// Mismatch is a span found at the expected offsets with a different type
+type Mismatch struct {
+	Expected Redaction `json:"expected"`
+	Got      Redaction `json:"got"`
+}
+
+// Empty reports whether the redactions matched the expected findings exactly
+func (d Diff) Empty() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0 && len(d.Mismatched) == 0
+}
+
+// Compare checks got against expected for golden tests. Redactions match when
+// their offsets are equal; a match of a different type is a mismatch. Other
+// fields, such as Replacement, are not compared. Each list of the Diff is in
+// document order.
+func Compare(expected, got []Redaction) Diff {
+	type span struct{ start, end int }
+	unmatched := make(map[span][]Redaction, len(got))
+	for _, redaction := range got {
+		key := span{redaction.Start, redaction.End}
+		unmatched[key] = append(unmatched[key], redaction)
+	}
+
+	var diff Diff
+	for _, want := range expected {
+		key := span{want.Start, want.End}
+		candidates := unmatched[key]
+		if len(candidates) == 0 {
+			diff.Missing = append(diff.Missing, want)
+			continue
+		}
+
+		// Prefer a candidate of the expected type, so duplicate spans pair up
+		// by type before any are reported as mismatched
+		pick := 0
+		for i, candidate := range candidates {
+			if candidate.Type == want.Type {
+				pick = i
+				break
+			}
+		}
+		if candidates[pick].Type != want.Type {
+			diff.Mismatched = append(diff.Mismatched, Mismatch{Expected: want, Got: candidates[pick]})
+		}
+		unmatched[key] = append(candidates[:pick], candidates[pick+1:]...)
+	}
+
+	for _, redaction := range got {
+		key := span{redaction.Start, redaction.End}
+		if remaining := unmatched[key]; len(remaining) > 0 {
+			diff.Extra = append(diff.Extra, remaining...)
+			delete(unmatched, key)
+		}
+	}
+
+	sortRedactionsAscending(diff.Missing)
+	sortRedactionsAscending(diff.Extra)
+	sort.SliceStable(diff.Mismatched, func(i, j int) bool {
+		return diff.Mismatched[i].Expected.Start < diff.Mismatched[j].Expected.Start
+	})
+	return diff
+}
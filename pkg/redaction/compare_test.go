@@ -0,0 +1,68 @@
+package redaction
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	expected := []Redaction{
+		{Type: TypeEmail, Start: 0, End: 16},
+		{Type: TypeSSN, Start: 20, End: 31},
+		{Type: TypePhone, Start: 40, End: 52},
+	}
+
+	t.Run("identical", func(t *testing.T) {
+		if diff := Compare(expected, expected); !diff.Empty() {
+			t.Errorf("Expected no differences, got %+v", diff)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		diff := Compare(expected, expected[:2])
+		if len(diff.Missing) != 1 || diff.Missing[0].Type != TypePhone || len(diff.Extra) != 0 || len(diff.Mismatched) != 0 {
+			t.Errorf("Expected the phone to be missing, got %+v", diff)
+		}
+	})
+
+	t.Run("extra", func(t *testing.T) {
+		got := append([]Redaction{{Type: TypeIPAddress, Start: 60, End: 71}}, expected...)
+		diff := Compare(expected, got)
+		if len(diff.Extra) != 1 || diff.Extra[0].Start != 60 || len(diff.Missing) != 0 || len(diff.Mismatched) != 0 {
+			t.Errorf("Expected one extra redaction, got %+v", diff)
+		}
+	})
+
+	t.Run("mismatched type", func(t *testing.T) {
+		got := []Redaction{expected[0], {Type: TypeITIN, Start: 20, End: 31}, expected[2]}
+		diff := Compare(expected, got)
+		if len(diff.Mismatched) != 1 || len(diff.Missing) != 0 || len(diff.Extra) != 0 {
+			t.Fatalf("Expected one mismatch, got %+v", diff)
+		}
+		if mismatch := diff.Mismatched[0]; mismatch.Expected.Type != TypeSSN || mismatch.Got.Type != TypeITIN {
+			t.Errorf("Expected SSN found as ITIN, got %+v", mismatch)
+		}
+	})
+
+	t.Run("shifted offsets", func(t *testing.T) {
+		got := []Redaction{expected[0], {Type: TypeSSN, Start: 21, End: 31}, expected[2]}
+		diff := Compare(expected, got)
+		if len(diff.Missing) != 1 || diff.Missing[0].Start != 20 || len(diff.Extra) != 1 || diff.Extra[0].Start != 21 {
+			t.Errorf("Expected a shifted span to be missing and extra, got %+v", diff)
+		}
+	})
+
+	t.Run("golden test", func(t *testing.T) {
+		result, err := NewEngine().RedactText(context.Background(), &Request{
+			Text: "Mail john@example.com or call 555-123-4567",
+			Mode: ModeReplace,
+		})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		golden := []Redaction{{Type: TypeEmail, Start: 5, End: 21}, {Type: TypePhone, Start: 30, End: 42}}
+		if diff := Compare(golden, result.Redactions); !diff.Empty() {
+			t.Errorf("Expected the result to match the golden findings, got %+v", diff)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package redaction
+
+import "strings"
+
+// frameworksMetadataKey is the Redaction.Metadata key listing the compliance
+// frameworks that require a redaction
+const frameworksMetadataKey = "frameworks"
+
+// complianceFrameworks maps each known compliance framework, by its
+// canonical name, to the types it requires redacted
+var complianceFrameworks = map[string][]Type{
+	// HIPAA Safe Harbor identifiers
+	"HIPAA": {
+		TypeName, TypeAddress, TypeZipCode, TypePoBox, TypeGeoCoordinate, TypeDate,
+		TypePhone, TypeEmail, TypeSSN, TypeMRN, TypeBankAccount, TypeLink, TypeIPAddress,
+	},
+	"GDPR": {
+		TypeName, TypeAddress, TypeEmail, TypePhone, TypeIPAddress, TypeMACAddress,
+		TypeGeoCoordinate, TypeLocation, TypeSocialHandle, TypeIBAN,
+		TypeUKNationalInsurance, TypeUKNHSNumber, TypeUKPostcode, TypeUKPhoneNumber,
+		TypeUKMobileNumber, TypeUKIBAN, TypeUKDrivingLicense, TypeUKPassportNumber,
+	},
+	"PCI-DSS": {TypeCreditCard},
+	"GLBA":    {TypeSSN, TypeITIN, TypeCreditCard, TypeRoutingNumber, TypeBankAccount},
+}
+
+// attributeFrameworks records, in each redaction's metadata, which of the
+// frameworks in the request's Context.ComplianceReqs require its type.
+// Unknown frameworks are ignored; names match case-insensitively and
+// underscores stand for dashes, so "pci_dss" names PCI-DSS.
+func attributeFrameworks(request *Request, redactions []Redaction) {
+	if request.Context == nil || len(request.Context.ComplianceReqs) == 0 {
+		return
+	}
+
+	required := make(map[Type][]string)
+	for _, framework := range request.Context.ComplianceReqs {
+		name := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(framework), "_", "-"))
+		for _, redactionType := range complianceFrameworks[name] {
+			if !containsString(required[redactionType], name) {
+				required[redactionType] = append(required[redactionType], name)
+			}
+		}
+	}
+
+	for i := range redactions {
+		frameworks, exists := required[redactions[i].Type]
+		if !exists {
+			continue
+		}
+		if redactions[i].Metadata == nil {
+			redactions[i].Metadata = make(map[string]interface{})
+		}
+		redactions[i].Metadata[frameworksMetadataKey] = append([]string(nil), frameworks...)
+	}
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, existing := range values {
+		if existing == value {
+			return true
+		}
+	}
+	return false
+}
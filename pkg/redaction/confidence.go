@@ -0,0 +1,33 @@
+package redaction
+
+// ConfidenceFunc computes the confidence of a built-in pattern match from its
+// type, its matched text and up to 20 bytes of text on either side
+type ConfidenceFunc func(redactionType Type, match string, context string) float64
+
+// SetConfidenceFunc calibrates the confidence of every built-in pattern match
+// with fn instead of the fixed levels, before the request's MinConfidence,
+// require_context and validated_only checks. A nil fn restores the fixed
+// levels. Detector and custom pattern matches keep their own confidence.
+func (re *Engine) SetConfidenceFunc(fn ConfidenceFunc) {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.confidenceFunc = fn
+}
+
+// confidenceFuncSnapshot returns the engine's confidence function, or nil
+func (re *Engine) confidenceFuncSnapshot() ConfidenceFunc {
+	re.mutex.RLock()
+	defer re.mutex.RUnlock()
+
+	return re.confidenceFunc
+}
+
+// calibratedConfidence returns fn's confidence for the match at start:end of
+// src, or confidence unchanged when fn is nil
+func (re *Engine) calibratedConfidence(fn ConfidenceFunc, src matchSource, redactionType Type, start, end int, confidence float64) float64 {
+	if fn == nil {
+		return confidence
+	}
+	return fn(redactionType, src.slice(start, end), re.extractSourceContext(src, start, end))
+}
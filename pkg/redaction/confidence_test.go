@@ -0,0 +1,68 @@
+package redaction
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSetConfidenceFunc(t *testing.T) {
+	engine := NewEngine()
+	text := "Customer SSN: 123-45-6789 on file. Ticket ref 234-56-7890 was closed yesterday."
+
+	redactSSNs := func(minConfidence float64) []Redaction {
+		result, err := engine.RedactText(context.Background(), &Request{
+			Text:          text,
+			Mode:          ModeReplace,
+			MinConfidence: minConfidence,
+		})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		var ssns []Redaction
+		for _, redaction := range result.Redactions {
+			if redaction.Type == TypeSSN {
+				ssns = append(ssns, redaction)
+			}
+		}
+		return ssns
+	}
+
+	// The fixed level applies to both matches by default
+	for _, redaction := range redactSSNs(0) {
+		if redaction.Confidence != defaultConfidence {
+			t.Errorf("Expected default confidence for %q, got %v", redaction.Original, redaction.Confidence)
+		}
+	}
+
+	engine.SetConfidenceFunc(func(redactionType Type, match string, context string) float64 {
+		if redactionType == TypeSSN && strings.Contains(strings.ToLower(context), "ssn") {
+			return 0.99
+		}
+		return 0.4
+	})
+
+	ssns := redactSSNs(0)
+	if len(ssns) != 2 {
+		t.Fatalf("Expected 2 SSNs, got %+v", ssns)
+	}
+	for _, redaction := range ssns {
+		expected := 0.4
+		if redaction.Original == "123-45-6789" {
+			expected = 0.99
+		}
+		if redaction.Confidence != expected {
+			t.Errorf("Expected confidence %v for %q, got %v", expected, redaction.Original, redaction.Confidence)
+		}
+	}
+
+	// Calibrated confidence is what MinConfidence filters on
+	if ssns := redactSSNs(0.9); len(ssns) != 1 || ssns[0].Original != "123-45-6789" {
+		t.Errorf("Expected only the keyword-adjacent SSN, got %+v", ssns)
+	}
+
+	engine.SetConfidenceFunc(nil)
+	if ssns := redactSSNs(0.9); len(ssns) != 2 {
+		t.Errorf("Expected the fixed levels to be restored, got %+v", ssns)
+	}
+}
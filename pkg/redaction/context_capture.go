@@ -0,0 +1,88 @@
+package redaction
+
+import "strings"
+
+// contextCaptureOption selects what Redaction.Context holds: "raw" (the
+// default) is the surrounding source text, "redacted" masks the other
+// matches inside that window with their replacements, and "none" leaves
+// Context empty
+const contextCaptureOption = "context_capture"
+
+// Context capture modes accepted by contextCaptureOption
+const (
+	contextCaptureRaw      = "raw"
+	contextCaptureRedacted = "redacted"
+	contextCaptureNone     = "none"
+)
+
+// minimizationRequirement is the Context.ComplianceReqs entry asking for
+// data minimization, which disables context capture
+const minimizationRequirement = "DATA-MINIMIZATION"
+
+// contextCapture returns the context capture mode of a request
+func contextCapture(request *Request) string {
+	if request.Context != nil {
+		for _, requirement := range request.Context.ComplianceReqs {
+			name := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(requirement), "_", "-"))
+			if name == minimizationRequirement {
+				return contextCaptureNone
+			}
+		}
+	}
+
+	mode, _ := request.Options[contextCaptureOption].(string)
+	switch mode {
+	case contextCaptureRedacted, contextCaptureNone:
+		return mode
+	default:
+		return contextCaptureRaw
+	}
+}
+
+// applyContextCapture rewrites the Context of redactions and candidates as
+// the request's capture mode asks. redactions must be final, with their
+// replacements, since they are what masks the windows.
+func (re *Engine) applyContextCapture(src matchSource, request *Request, redactions, candidates []Redaction) {
+	switch contextCapture(request) {
+	case contextCaptureNone:
+		for _, spans := range [][]Redaction{redactions, candidates} {
+			for i := range spans {
+				spans[i].Context = ""
+			}
+		}
+	case contextCaptureRedacted:
+		for _, spans := range [][]Redaction{redactions, candidates} {
+			for i := range spans {
+				spans[i].Context = re.redactedContext(src, spans[i], redactions)
+			}
+		}
+	}
+}
+
+// redactedContext is the context window of target with every other
+// redaction in it replaced. A redaction straddling the window edge is
+// replaced whole, so no partial value leaks from either side.
+func (re *Engine) redactedContext(src matchSource, target Redaction, redactions []Redaction) string {
+	contextStart := maxInt(0, target.Start-20)
+	contextEnd := minInt(src.length(), target.End+20)
+
+	var builder strings.Builder
+	last := contextStart
+	for _, redaction := range redactions {
+		if redaction.End <= last || redaction.Start >= contextEnd {
+			continue
+		}
+		if redaction.Start == target.Start && redaction.End == target.End {
+			continue
+		}
+		if redaction.Start > last {
+			builder.WriteString(src.slice(last, redaction.Start))
+		}
+		builder.WriteString(redaction.Replacement)
+		last = redaction.End
+	}
+	if last < contextEnd {
+		builder.WriteString(src.slice(last, contextEnd))
+	}
+	return builder.String()
+}
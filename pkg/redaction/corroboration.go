@@ -0,0 +1,79 @@
+package redaction
+
+import "strings"
+
+// defaultCorroborationWindow is the window RequireCorroboration uses when
+// given none
+const defaultCorroborationWindow = 50
+
+// corroboration is the keyword evidence a type's matches need to be kept
+type corroboration struct {
+	keywords []string // Lowercased
+	window   int
+}
+
+// RequireCorroboration keeps matches of a type only when one of keywords
+// appears, case-insensitively, in the match or within window bytes either
+// side of it. This extends the keyword guard of context-dependent types,
+// such as passport numbers, to any type with a weak pattern. A window of zero
+// or less selects the default of 50 bytes; no keywords removes the
+// requirement.
+func (re *Engine) RequireCorroboration(redactionType Type, keywords []string, window int) {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	lowered := make([]string, 0, len(keywords))
+	for _, keyword := range keywords {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			lowered = append(lowered, strings.ToLower(keyword))
+		}
+	}
+	if window <= 0 {
+		window = defaultCorroborationWindow
+	}
+
+	// The rules are replaced rather than modified, so redactions in flight
+	// keep a consistent snapshot
+	rules := make(map[Type]corroboration, len(re.corroborations)+1)
+	for existing, rule := range re.corroborations {
+		rules[existing] = rule
+	}
+	if len(lowered) == 0 {
+		delete(rules, redactionType)
+	} else {
+		rules[redactionType] = corroboration{keywords: lowered, window: window}
+	}
+	re.corroborations = rules
+}
+
+// filterCorroborated drops the matches of types requiring corroboration that
+// have no keyword within their window
+func (re *Engine) filterCorroborated(src matchSource, redactions []Redaction) []Redaction {
+	re.mutex.RLock()
+	rules := re.corroborations
+	re.mutex.RUnlock()
+
+	if len(rules) == 0 {
+		return redactions
+	}
+
+	kept := redactions[:0]
+	for _, redaction := range redactions {
+		rule, exists := rules[redaction.Type]
+		if !exists || rule.corroborated(src, redaction) {
+			kept = append(kept, redaction)
+		}
+	}
+	return kept
+}
+
+// corroborated reports whether a keyword appears near a redaction
+func (c corroboration) corroborated(src matchSource, redaction Redaction) bool {
+	window := strings.ToLower(src.slice(maxInt(0, redaction.Start-c.window), minInt(src.length(), redaction.End+c.window)))
+	for _, keyword := range c.keywords {
+		if strings.Contains(window, keyword) {
+			return true
+		}
+	}
+	return false
+}
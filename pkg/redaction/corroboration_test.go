@@ -0,0 +1,48 @@
+package redaction
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRequireCorroboration(t *testing.T) {
+	engine := NewEngine()
+	passports := func(text string) int {
+		t.Helper()
+		result, err := engine.RedactText(context.Background(), &Request{Text: text, Mode: ModeReplace})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		count := 0
+		for _, redaction := range result.Redactions {
+			if redaction.Type == TypeUKPassportNumber {
+				count++
+			}
+		}
+		return count
+	}
+
+	bare := "Order reference 123456789 was shipped"
+	near := "Travel passport details: 123456789"
+	far := "Travel passport details are attached to the booking form, ref 123456789"
+
+	if passports(bare) != 1 {
+		t.Fatal("Expected a bare nine-digit number to be taken for a passport by default")
+	}
+
+	engine.RequireCorroboration(TypeUKPassportNumber, []string{"Passport"}, 20)
+	if passports(bare) != 0 {
+		t.Error("Expected the uncorroborated number to be dropped")
+	}
+	if passports(near) != 1 {
+		t.Error("Expected the number to be kept with a passport keyword in the window")
+	}
+	if passports(far) != 0 {
+		t.Error("Expected a keyword outside the window not to corroborate")
+	}
+
+	engine.RequireCorroboration(TypeUKPassportNumber, nil, 0)
+	if passports(bare) != 1 {
+		t.Error("Expected no keywords to remove the requirement")
+	}
+}
@@ -0,0 +1,170 @@
+package redaction
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// CSVOptions configures RedactCSV
+type CSVOptions struct {
+	// Request is the template for every cell, whether its column is typed
+	// or scanned for any type
+	Request *Request
+
+	// Comma is the field delimiter (default ','; use '\t' for TSV)
+	Comma rune
+
+	// Header reports whether the first row names the columns. The header row
+	// is written back unchanged.
+	Header bool
+
+	// Columns lists the columns scanned for any type, by header name or
+	// zero-based index. When both Columns and ColumnTypes are empty, every
+	// column is scanned.
+	Columns []string
+
+	// ColumnTypes maps a column, by header name or zero-based index, to the
+	// one type its values hold. Typed cells are redacted with RedactField
+	// semantics under the Request's settings.
+	ColumnTypes map[string]Type
+}
+
+// CSVResult summarises a CSV redaction
+type CSVResult struct {
+	Rows       int         `json:"rows"`       // Data rows, excluding the header
+	Redactions []Redaction `json:"redactions"` // Offsets are relative to the cell
+	Errors     []string    `json:"errors,omitempty"`
+}
+
+// RedactCSV redacts the selected columns of CSV or TSV data read from r and
+// writes the rows to w, re-quoting cells as needed. Columns that are not
+// selected pass through unchanged.
+func (re *Engine) RedactCSV(ctx context.Context, r io.Reader, w io.Writer, opts CSVOptions) (*CSVResult, error) {
+	request := segmentRequest(opts.Request)
+	if err := re.checkRequest(ctx, request, 0); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	writer := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+		writer.Comma = opts.Comma
+	}
+
+	result := &CSVResult{Redactions: []Redaction{}}
+	segments := &Result{}
+	var columns map[int]Type
+	line := 0
+
+	for {
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		default:
+		}
+
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return result, fmt.Errorf("error reading CSV: %w", err)
+		}
+		line++
+
+		if columns == nil {
+			var header []string
+			if opts.Header {
+				header = record
+			}
+			if columns, err = csvColumns(opts, header); err != nil {
+				return result, err
+			}
+			if opts.Header {
+				if err := writer.Write(record); err != nil {
+					return result, fmt.Errorf("error writing CSV: %w", err)
+				}
+				continue
+			}
+		}
+
+		for i, cell := range record {
+			redactionType, selected := columns[i]
+			if !selected && len(columns) > 0 {
+				continue
+			}
+
+			if redactionType == "" {
+				redacted, err := re.redactSegment(ctx, request, cell, nil, segments)
+				if err != nil {
+					return result, fmt.Errorf("row %d, column %d: %w", line, i, err)
+				}
+				record[i] = redacted
+				continue
+			}
+
+			field, err := re.redactField(ctx, cell, redactionType, request, false)
+			if err != nil {
+				return result, fmt.Errorf("row %d, column %d: %w", line, i, err)
+			}
+			result.Redactions = append(result.Redactions, field.Redactions...)
+			record[i] = field.RedactedText
+		}
+
+		if err := writer.Write(record); err != nil {
+			return result, fmt.Errorf("error writing CSV: %w", err)
+		}
+		result.Rows++
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return result, fmt.Errorf("error writing CSV: %w", err)
+	}
+
+	result.Redactions = append(result.Redactions, segments.Redactions...)
+	result.Errors = segments.Errors
+	return result, nil
+}
+
+// csvColumns resolves the selected columns to their indexes, mapping each to
+// its type hint or to "" for columns scanned for any type. An empty map
+// selects every column.
+func csvColumns(opts CSVOptions, header []string) (map[int]Type, error) {
+	columns := make(map[int]Type)
+	for _, column := range opts.Columns {
+		index, err := csvColumnIndex(column, header)
+		if err != nil {
+			return nil, err
+		}
+		columns[index] = ""
+	}
+	for column, redactionType := range opts.ColumnTypes {
+		index, err := csvColumnIndex(column, header)
+		if err != nil {
+			return nil, err
+		}
+		columns[index] = redactionType
+	}
+	return columns, nil
+}
+
+// csvColumnIndex finds a column by header name, falling back to a zero-based
+// index
+func csvColumnIndex(column string, header []string) (int, error) {
+	for i, name := range header {
+		if name == column {
+			return i, nil
+		}
+	}
+	if index, err := strconv.Atoi(column); err == nil && index >= 0 {
+		return index, nil
+	}
+	return 0, fmt.Errorf("unknown CSV column: %s", column)
+}
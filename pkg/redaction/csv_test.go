@@ -0,0 +1,89 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRedactCSV(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+
+	input := "name,ssn,notes\n" +
+		"Jane,123-45-6789,\"Called on 2024-01-05, left message\"\n" +
+		"John,not an ssn,jane@example.com\n"
+
+	var output strings.Builder
+	result, err := engine.RedactCSV(ctx, strings.NewReader(input), &output, CSVOptions{
+		Header:      true,
+		ColumnTypes: map[string]Type{"ssn": TypeSSN},
+	})
+	if err != nil {
+		t.Fatalf("RedactCSV failed: %v", err)
+	}
+
+	expected := "name,ssn,notes\n" +
+		"Jane,[SSN_REDACTED],\"Called on 2024-01-05, left message\"\n" +
+		"John,not an ssn,jane@example.com\n"
+	if output.String() != expected {
+		t.Errorf("Unexpected CSV output:\n%s", output.String())
+	}
+	if result.Rows != 2 || len(result.Redactions) != 1 || result.Redactions[0].Type != TypeSSN {
+		t.Errorf("Expected 2 rows and one SSN redaction, got %+v", result)
+	}
+
+	// TSV with columns selected by index and scanned for any type
+	output.Reset()
+	_, err = engine.RedactCSV(ctx, strings.NewReader("Jane\tjane@example.com\n"), &output, CSVOptions{
+		Comma:   '\t',
+		Columns: []string{"1"},
+	})
+	if err != nil {
+		t.Fatalf("RedactCSV failed: %v", err)
+	}
+	if output.String() != "Jane\t[EMAIL_REDACTED]\n" {
+		t.Errorf("Unexpected TSV output: %q", output.String())
+	}
+
+	// Strict fields reject values that do not match their column type
+	engine.SetStrictFields(true)
+	_, err = engine.RedactCSV(ctx, strings.NewReader(input), &output, CSVOptions{
+		Header:      true,
+		ColumnTypes: map[string]Type{"ssn": TypeSSN},
+	})
+	if !errors.Is(err, ErrFieldMismatch) {
+		t.Errorf("Expected ErrFieldMismatch, got %v", err)
+	}
+
+	_, err = engine.RedactCSV(ctx, strings.NewReader(input), &output, CSVOptions{Columns: []string{"missing"}})
+	if err == nil {
+		t.Error("Expected an error for an unknown column")
+	}
+}
+
+func TestRedactCSVTypedColumnsUseRequest(t *testing.T) {
+	engine := NewEngine()
+	input := "ssn,account\n123-45-6789,12345678901\n"
+
+	var output strings.Builder
+	_, err := engine.RedactCSV(context.Background(), strings.NewReader(input), &output, CSVOptions{
+		Request: &Request{
+			Mode: ModeReplace,
+			ReplacerFunc: func(redaction Redaction) string {
+				return "<" + string(redaction.Type) + ">"
+			},
+		},
+		Header:      true,
+		ColumnTypes: map[string]Type{"ssn": TypeSSN, "account": TypeBankAccount},
+	})
+	if err != nil {
+		t.Fatalf("RedactCSV failed: %v", err)
+	}
+
+	expected := "ssn,account\n<ssn>,<bank_account>\n"
+	if output.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, output.String())
+	}
+}
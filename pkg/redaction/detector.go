@@ -0,0 +1,119 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Detector finds sensitive spans the built-in patterns cannot, such as entities
+// reported by an external recognizer. Returned redactions must set Type, Start
+// and End (byte offsets into text); the engine fills in the original, the
+// replacement and the context, and resolves overlaps with pattern matches.
+type Detector interface {
+	// Name identifies the detector in Result.Errors
+	Name() string
+
+	// Detect returns the sensitive spans found in text
+	Detect(ctx context.Context, text string) ([]Redaction, error)
+}
+
+// TypedDetector is a Detector that declares the types it reports, so that
+// requests naming those types pass validation
+type TypedDetector interface {
+	Detector
+
+	// Types returns every type Detect may report
+	Types() []Type
+}
+
+// AddDetector registers a detector that runs alongside the built-in patterns
+func (re *Engine) AddDetector(detector Detector) {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.detectors = append(re.detectors, detector)
+}
+
+// runDetectors runs every registered detector over src. A failing detector is
+// reported in the returned errors and does not stop the others.
+func (re *Engine) runDetectors(ctx context.Context, src matchSource) ([]Redaction, []error) {
+	re.mutex.RLock()
+	detectors := re.detectors
+	re.mutex.RUnlock()
+
+	if len(detectors) == 0 {
+		return nil, nil
+	}
+
+	text := src.slice(0, src.length())
+	var redactions []Redaction
+	var errs []error
+
+	for _, detector := range detectors {
+		found, err := detector.Detect(ctx, text)
+		if err != nil {
+			errs = append(errs, &detectorError{name: detector.Name(), err: err})
+			continue
+		}
+
+		for _, redaction := range found {
+			if redaction.Start < 0 || redaction.End > len(text) || redaction.Start >= redaction.End {
+				errs = append(errs, fmt.Errorf("detector %s: span %d-%d out of range",
+					detector.Name(), redaction.Start, redaction.End))
+				continue
+			}
+			redaction.Original = text[redaction.Start:redaction.End]
+			if redaction.Confidence == 0 {
+				redaction.Confidence = defaultConfidence
+			}
+			redactions = append(redactions, redaction)
+		}
+	}
+
+	return redactions, errs
+}
+
+// detectorError records the failure of a whole detector run
+type detectorError struct {
+	name string
+	err  error
+}
+
+func (e *detectorError) Error() string { return fmt.Sprintf("detector %s: %v", e.name, e.err) }
+
+func (e *detectorError) Unwrap() error { return e.err }
+
+// detectorFailure returns an aggregate error when every registered detector
+// failed. Partial failures are reported only through Result.Errors.
+func (re *Engine) detectorFailure(errs []error) error {
+	re.mutex.RLock()
+	count := len(re.detectors)
+	re.mutex.RUnlock()
+
+	var failed []error
+	for _, err := range errs {
+		var runErr *detectorError
+		if errors.As(err, &runErr) {
+			failed = append(failed, err)
+		}
+	}
+
+	if count == 0 || len(failed) < count {
+		return nil
+	}
+	return fmt.Errorf("all detectors failed: %w", errors.Join(failed...))
+}
+
+// errorStrings converts errors into their messages for Result.Errors
+func errorStrings(errs []error) []string {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return messages
+}
@@ -0,0 +1,81 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// staticDetector reports every occurrence of a fixed word
+type staticDetector struct {
+	word string
+	kind Type
+}
+
+func (d *staticDetector) Name() string { return "static" }
+
+func (d *staticDetector) Detect(_ context.Context, text string) ([]Redaction, error) {
+	var found []Redaction
+	offset := 0
+	for {
+		index := strings.Index(text[offset:], d.word)
+		if index < 0 {
+			return found, nil
+		}
+		start := offset + index
+		found = append(found, Redaction{Type: d.kind, Start: start, End: start + len(d.word)})
+		offset = start + len(d.word)
+	}
+}
+
+// typedStaticDetector is a staticDetector that declares its type
+type typedStaticDetector struct {
+	staticDetector
+}
+
+func (d *typedStaticDetector) Types() []Type { return []Type{d.kind} }
+
+// failingDetector always errors
+type failingDetector struct{}
+
+func (failingDetector) Name() string { return "failing" }
+
+func (failingDetector) Detect(context.Context, string) ([]Redaction, error) {
+	return nil, errors.New("recognizer unavailable")
+}
+
+func TestDetectorPartialResults(t *testing.T) {
+	text := "Alice emailed alice@example.com"
+
+	t.Run("One failing detector", func(t *testing.T) {
+		engine := NewEngine()
+		engine.AddDetector(failingDetector{})
+		engine.AddDetector(&staticDetector{word: "Alice", kind: TypeName})
+
+		result, err := engine.RedactText(context.Background(), &Request{Text: text, Mode: ModeReplace})
+		if err != nil {
+			t.Fatalf("Expected no error when only some detectors fail, got %v", err)
+		}
+
+		if result.RedactedText != "[NAME_REDACTED] emailed [EMAIL_REDACTED]" {
+			t.Errorf("Unexpected redacted text: %s", result.RedactedText)
+		}
+		if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "failing") {
+			t.Errorf("Expected the failing detector in Result.Errors, got %v", result.Errors)
+		}
+	})
+
+	t.Run("All detectors failing", func(t *testing.T) {
+		engine := NewEngine()
+		engine.AddDetector(failingDetector{})
+
+		result, err := engine.RedactText(context.Background(), &Request{Text: text, Mode: ModeReplace})
+		if err == nil {
+			t.Fatal("Expected an aggregate error when every detector fails")
+		}
+		if result == nil || !strings.Contains(result.RedactedText, "[EMAIL_REDACTED]") {
+			t.Errorf("Expected the partial result to keep pattern redactions, got %+v", result)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package redaction
+
+import (
+	"regexp"
+	"strings"
+)
+
+// emailHeadersOption, when true, recognizes the header lines of pasted or
+// forwarded email, such as From: and Cc:, and redacts the display names and
+// addresses in their values. Header names are kept.
+const emailHeadersOption = "email_headers"
+
+// emailHeaderMetadataKey is the Redaction.Metadata key naming the header a
+// name or address was found in
+const emailHeaderMetadataKey = "email_header"
+
+// addressHeaderPattern matches an RFC 822 address header at the start of a
+// line, possibly quoted with ">", capturing its name and its value along with
+// any folded continuation lines
+var addressHeaderPattern = regexp.MustCompile(
+	`(?im)^(?:>[ \t]*)*(?P<header>From|To|Cc|Bcc|Reply-To|Sender|Delivered-To|Return-Path)[ \t]*:[ \t]*` +
+		`(?P<value>[^\r\n]*(?:\r?\n[ \t]+[^\r\n]*)*)`)
+
+// mailboxPattern matches one mailbox in an address header value: a quoted or
+// bare display name followed by an address in angle brackets, or a bare
+// address
+var mailboxPattern = regexp.MustCompile(
+	`(?:"(?P<quoted>(?:[^"\\]|\\.)*)"|(?P<name>[^",<>\s][^",<>]*?))?\s*<(?P<angle>[^<>\s]*)>|(?P<bare>[^\s,<>"]+@[^\s,<>"]+)`)
+
+// mailboxGroups are the capture groups of mailboxPattern and the type of
+// their contents
+var mailboxGroups = []struct {
+	name          string
+	redactionType Type
+}{
+	{"quoted", TypeName},
+	{"name", TypeName},
+	{"angle", TypeEmail},
+	{"bare", TypeEmail},
+}
+
+// collectEmailHeaders returns the display names and addresses in the address
+// headers of src
+func (re *Engine) collectEmailHeaders(src matchSource, minConfidence float64) []Redaction {
+	if defaultConfidence < minConfidence {
+		return nil
+	}
+
+	headerGroup := addressHeaderPattern.SubexpIndex("header")
+	valueGroup := addressHeaderPattern.SubexpIndex("value")
+
+	var redactions []Redaction
+	for _, header := range src.findAllSubmatch(addressHeaderPattern) {
+		valueStart, valueEnd, ok := targetSpan(header, valueGroup)
+		if !ok {
+			continue
+		}
+		name := src.slice(header[2*headerGroup], header[2*headerGroup+1])
+
+		value := src.slice(valueStart, valueEnd)
+		for _, mailbox := range mailboxPattern.FindAllStringSubmatchIndex(value, -1) {
+			for _, group := range mailboxGroups {
+				start, end, ok := targetSpan(mailbox, mailboxPattern.SubexpIndex(group.name))
+				if !ok || strings.TrimSpace(value[start:end]) == "" {
+					continue
+				}
+				if group.redactionType == TypeEmail && !strings.Contains(value[start:end], "@") {
+					continue
+				}
+
+				redaction := re.patternRedaction(src, group.redactionType, valueStart+start, valueStart+end, defaultConfidence)
+				redaction.Metadata = map[string]interface{}{emailHeaderMetadataKey: name}
+				redactions = append(redactions, redaction)
+			}
+		}
+	}
+	return redactions
+}
@@ -0,0 +1,62 @@
+package redaction
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestEmailHeaders(t *testing.T) {
+	engine := NewEngine()
+	text := "---------- Forwarded message ---------\n" +
+		"From: \"John Smith\" <john.smith@example.com>\n" +
+		"Date: Mon, 3 Jun 2024\n" +
+		"Subject: Re: contract for jane@example.org\n" +
+		"To: Jane Doe <jane@example.org>, ops@example.net,\n" +
+		"    \"Legal Team\" <legal@internal>\n" +
+		"Cc: <audit@example.com>\n" +
+		"\n" +
+		"Please see below."
+
+	result, err := engine.RedactText(context.Background(), &Request{
+		Text:    text,
+		Mode:    ModeReplace,
+		Options: map[string]interface{}{emailHeadersOption: true},
+	})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+
+	for _, secret := range []string{
+		"john.smith@example.com", "jane@example.org", "ops@example.net", "legal@internal", "audit@example.com",
+		"John Smith", "Jane Doe", "Legal Team",
+	} {
+		if strings.Contains(result.RedactedText, secret) {
+			t.Errorf("Expected %q to be redacted, got:\n%s", secret, result.RedactedText)
+		}
+	}
+	for _, kept := range []string{"\nFrom: \"", "\nSubject: Re: contract for ", "\nTo: ", "\nCc: <", "Please see below."} {
+		if !strings.Contains(result.RedactedText, kept) {
+			t.Errorf("Expected %q to be kept, got:\n%s", kept, result.RedactedText)
+		}
+	}
+
+	names := make(map[string]int)
+	for _, redaction := range result.Redactions {
+		if header, ok := redaction.Metadata[emailHeaderMetadataKey].(string); ok && redaction.Type == TypeName {
+			names[header]++
+		}
+	}
+	if len(names) != 2 || names["From"] != 1 || names["To"] != 2 {
+		t.Errorf("Expected one name in From and two in To, got %v", names)
+	}
+
+	// Without the option, names in headers are left alone
+	plain, err := engine.RedactText(context.Background(), &Request{Text: text, Mode: ModeReplace})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+	if !strings.Contains(plain.RedactedText, "John Smith") {
+		t.Errorf("Expected names kept without the option, got:\n%s", plain.RedactedText)
+	}
+}
@@ -0,0 +1,52 @@
+package redaction
+
+import (
+	"bytes"
+	"strings"
+)
+
+// emptyPlaceholderOption substitutes a placeholder when redaction leaves the
+// output empty or all whitespace, as removing a document that is one match
+// does, for consumers that treat empty output as an error. It takes the
+// placeholder string, or true for defaultEmptyPlaceholder.
+const emptyPlaceholderOption = "empty_placeholder"
+
+// defaultEmptyPlaceholder is the placeholder for empty_placeholder: true
+const defaultEmptyPlaceholder = "[REDACTED]"
+
+// fillEmptyOutput returns the placeholder in place of output when redactions
+// emptied it and the request asks for one, reporting whether it did. Text
+// that was empty to begin with is left alone.
+func fillEmptyOutput(output string, redactions []Redaction, request *Request) (string, bool) {
+	if len(redactions) == 0 || strings.TrimSpace(output) != "" {
+		return output, false
+	}
+	if placeholder := emptyPlaceholder(request); placeholder != "" {
+		return placeholder, true
+	}
+	return output, false
+}
+
+// fillEmptyBytes is fillEmptyOutput for the output of RedactBytes
+func fillEmptyBytes(output []byte, redactions []Redaction, request *Request) ([]byte, bool) {
+	if len(redactions) == 0 || len(bytes.TrimSpace(output)) > 0 {
+		return output, false
+	}
+	if placeholder := emptyPlaceholder(request); placeholder != "" {
+		return []byte(placeholder), true
+	}
+	return output, false
+}
+
+// emptyPlaceholder returns the placeholder the request asks for, or ""
+func emptyPlaceholder(request *Request) string {
+	switch value := request.Options[emptyPlaceholderOption].(type) {
+	case string:
+		return value
+	case bool:
+		if value {
+			return defaultEmptyPlaceholder
+		}
+	}
+	return ""
+}
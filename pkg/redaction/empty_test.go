@@ -0,0 +1,42 @@
+package redaction
+
+import (
+	"context"
+	"testing"
+)
+
+func TestEmptyPlaceholder(t *testing.T) {
+	engine := NewEngine()
+	redact := func(text string, options map[string]interface{}) *Result {
+		t.Helper()
+		result, err := engine.RedactText(context.Background(), &Request{Text: text, Mode: ModeRemove, Options: options})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		return result
+	}
+
+	if result := redact("123-45-6789", nil); result.RedactedText != "" || result.EmptyOutputReplaced {
+		t.Errorf("Expected empty output without the option, got %q", result.RedactedText)
+	}
+
+	result := redact(" 123-45-6789\n", map[string]interface{}{"empty_placeholder": true})
+	if result.RedactedText != "[REDACTED]" || !result.EmptyOutputReplaced {
+		t.Errorf("Expected the default placeholder, got %q (replaced %v)", result.RedactedText, result.EmptyOutputReplaced)
+	}
+
+	result = redact("123-45-6789", map[string]interface{}{"empty_placeholder": "[EMPTY]"})
+	if result.RedactedText != "[EMPTY]" || !result.EmptyOutputReplaced {
+		t.Errorf("Expected the custom placeholder, got %q", result.RedactedText)
+	}
+
+	result = redact("SSN 123-45-6789", map[string]interface{}{"empty_placeholder": true})
+	if result.RedactedText != "SSN " || result.EmptyOutputReplaced {
+		t.Errorf("Expected non-empty output to be kept, got %q", result.RedactedText)
+	}
+
+	result = redact("   ", map[string]interface{}{"empty_placeholder": true})
+	if result.RedactedText != "   " || result.EmptyOutputReplaced {
+		t.Errorf("Expected text without redactions to be kept, got %q", result.RedactedText)
+	}
+}
@@ -5,12 +5,19 @@ package redaction
 import (
 	"context"
 	"crypto/rand"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/censgate/redact/pkg/strategies"
 )
 
 // Type represents the type of sensitive data
@@ -18,28 +25,54 @@ type Type string
 
 // Redaction type constants for different types of sensitive data
 const (
-	TypeEmail      Type = "email"
-	TypePhone      Type = "phone"
-	TypeCreditCard Type = "credit_card"
-	TypeSSN        Type = "ssn"
-	TypeAddress    Type = "address"
-	TypeName       Type = "name"
-	TypeIPAddress  Type = "ip_address"
-	TypeDate       Type = "date"
-	TypeTime       Type = "time"
-	TypeLink       Type = "link"
-	TypeZipCode    Type = "zip_code"
-	TypePoBox      Type = "po_box"
-	TypeBTCAddress Type = "btc_address"
-	TypeMD5Hex     Type = "md5_hex"
-	TypeSHA1Hex    Type = "sha1_hex"
-	TypeSHA256Hex  Type = "sha256_hex"
-	TypeGUID       Type = "guid"
-	TypeISBN       Type = "isbn"
-	TypeMACAddress Type = "mac_address"
-	TypeIBAN       Type = "iban"
-	TypeGitRepo    Type = "git_repo"
-	TypeCustom     Type = "custom"
+	TypeEmail        Type = "email"
+	TypePhone        Type = "phone"
+	TypeCreditCard   Type = "credit_card"
+	TypeSSN          Type = "ssn"
+	TypeITIN         Type = "itin"
+	TypeAddress      Type = "address"
+	TypeName         Type = "name"
+	TypeIPAddress    Type = "ip_address"
+	TypeDate         Type = "date"
+	TypeTime         Type = "time"
+	TypeLink         Type = "link"
+	TypeZipCode      Type = "zip_code"
+	TypePoBox        Type = "po_box"
+	TypeBTCAddress   Type = "btc_address"
+	TypeMD5Hex       Type = "md5_hex"
+	TypeSHA1Hex      Type = "sha1_hex"
+	TypeSHA256Hex    Type = "sha256_hex"
+	TypeGUID         Type = "guid"
+	TypeISBN         Type = "isbn"
+	TypeMACAddress   Type = "mac_address"
+	TypeIBAN         Type = "iban"
+	TypeGitRepo      Type = "git_repo"
+	TypeJWT          Type = "jwt"
+	TypeWebhookURL   Type = "webhook_url"
+	TypeSocialHandle Type = "social_handle"
+
+	// TypeGeoCoordinate is a latitude/longitude pair
+	TypeGeoCoordinate Type = "geo_coordinate"
+
+	// TypeCurrencyAmount is a $, £ or € amount
+	TypeCurrencyAmount Type = "currency_amount"
+	TypeCustom         Type = "custom"
+
+	// TypePrivateKey is a PEM or OpenSSH private key block
+	TypePrivateKey Type = "private_key"
+
+	// TypeSecretAssignment is the value of a KEY=value or KEY: value pair
+	// whose key names a secret
+	TypeSecretAssignment Type = "secret_assignment"
+
+	// US banking identifier types
+	TypeRoutingNumber Type = "routing_number"
+	TypeBankAccount   Type = "bank_account"
+
+	// Entity types reported by detectors rather than patterns
+	TypeOrganization Type = "organization"
+	TypeLocation     Type = "location"
+	TypeHighEntropy  Type = "high_entropy"
 
 	// UK-specific identifier types
 	TypeUKNationalInsurance Type = "uk_national_insurance"
@@ -52,6 +85,17 @@ const (
 	TypeUKCompanyNumber     Type = "uk_company_number"
 	TypeUKDrivingLicense    Type = "uk_driving_license"
 	TypeUKPassportNumber    Type = "uk_passport_number"
+
+	// US federal identifier types
+	TypeUSPassport Type = "us_passport"
+	TypeMilitaryID Type = "military_id"
+
+	// TypeMRN is a medical record number
+	TypeMRN Type = "mrn"
+
+	// Vehicle identifier types
+	TypeVIN          Type = "vin"
+	TypeLicensePlate Type = "license_plate"
 )
 
 // Result represents the result of a redaction operation
@@ -61,6 +105,74 @@ type Result struct {
 	Redactions   []Redaction `json:"redactions"`
 	Token        string      `json:"token,omitempty"`
 	Timestamp    time.Time   `json:"timestamp"`
+	Errors       []string    `json:"errors,omitempty"` // Non-fatal detector and strategy failures
+
+	// Mapping maps replacements back to their originals for bulk reversal.
+	// It contains plaintext and is only set when Request.IncludeMapping is.
+	Mapping map[string]string `json:"mapping,omitempty"`
+
+	// Candidates holds the matches that lost overlap resolution. They are
+	// not applied to the text and are only set under the "annotate" overlap
+	// policy.
+	Candidates []Redaction `json:"candidates,omitempty"`
+
+	// Truncated reports that the request's redaction budget ran out, so
+	// RedactedText stops before the first redaction that did not fit
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Advisories are risky-looking tokens no detector matched. They are
+	// left in the text and only set when the "advisories" option is.
+	Advisories []Advisory `json:"advisories,omitempty"`
+
+	// EmptyOutputReplaced reports that redaction left nothing but whitespace
+	// and RedactedText holds the "empty_placeholder" option's placeholder
+	EmptyOutputReplaced bool `json:"empty_output_replaced,omitempty"`
+
+	// OriginalLength and RedactedLength are the byte lengths of the original
+	// and redacted text, and GrowthRatio the second over the first (0 for an
+	// empty original)
+	OriginalLength int     `json:"original_length"`
+	RedactedLength int     `json:"redacted_length"`
+	GrowthRatio    float64 `json:"growth_ratio"`
+}
+
+// Summary aggregates the redactions of a result by type
+type Summary struct {
+	Total  int          `json:"total"`
+	ByType map[Type]int `json:"by_type"`
+}
+
+// Summary counts the result's redactions by type
+func (r *Result) Summary() *Summary {
+	summary := &Summary{ByType: make(map[Type]int)}
+	for _, redaction := range r.Redactions {
+		summary.Total++
+		summary.ByType[redaction.Type]++
+	}
+	return summary
+}
+
+// HighlightRanges returns the [start, end) byte ranges of the original text
+// covered by the result's redactions, for highlighting. Ranges are sorted,
+// and overlapping or touching spans are merged into one.
+func (r *Result) HighlightRanges() [][2]int {
+	spans := make([][2]int, 0, len(r.Redactions))
+	for _, redaction := range r.Redactions {
+		spans = append(spans, [2]int{redaction.Start, redaction.End})
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i][0] < spans[j][0]
+	})
+
+	ranges := spans[:0]
+	for _, span := range spans {
+		if last := len(ranges) - 1; last >= 0 && span[0] <= ranges[last][1] {
+			ranges[last][1] = maxInt(ranges[last][1], span[1])
+			continue
+		}
+		ranges = append(ranges, span)
+	}
+	return ranges
 }
 
 // Redaction represents a single redaction operation
@@ -72,18 +184,82 @@ type Redaction struct {
 	Replacement string  `json:"replacement"`
 	Confidence  float64 `json:"confidence"`
 	Context     string  `json:"context,omitempty"`
+	Token       string  `json:"token,omitempty"`  // Per-span token (tokenize mode)
+	Source      string  `json:"source,omitempty"` // What produced a custom match, e.g. "literal"
+
+	// RuneStart and RuneEnd are Start and End counted in Unicode code points
+	// rather than bytes, for consumers that index text by character
+	RuneStart int `json:"rune_start"`
+	RuneEnd   int `json:"rune_end"`
+
+	// Metadata annotates the redaction, e.g. with the compliance frameworks
+	// that require it under "frameworks"
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // Engine handles PII/PHI detection and redaction
 // Implements RedactionProvider interface
 type Engine struct {
-	patterns map[Type]*regexp.Regexp
-	tokens   map[string]TokenInfo
-	mutex    sync.RWMutex
+	patterns   map[Type]*regexp.Regexp
+	validators map[Type]Validator
+	tokens     map[string]TokenInfo
+	detectors  []Detector
+	mutex      sync.RWMutex
+
+	// matchCounts holds lifetime match counts per type, reset by ResetStats
+	matchCounts map[Type]int
+
+	// anchoredPatterns caches whole-value patterns for RedactField by source
+	anchoredPatterns map[string]*regexp.Regexp
+	strictFields     bool
+
+	// policyCache holds compiled policy rule sets by policyRulesKey, with
+	// lifetime hit and miss counts reset by ResetStats
+	policyCache       map[string]*compiledPolicyRules
+	policyCacheHits   int
+	policyCacheMisses int
+
+	// Lifecycle hooks run around RedactText in registration order
+	beforeHooks []func(*Request)
+	afterHooks  []func(*Result)
+
+	// combined caches the single-pass pattern used when combined scanning is on
+	combined            *combinedScan
+	combinedScanEnabled bool
+
+	// strategyRegistry resolves the replacement strategies named by policy rules
+	strategyRegistry strategies.StrategyRegistry
+
+	// replacements overrides the replace mode placeholders of replacementMap
+	replacements map[Type]string
+
+	// mrnFormats are the facility-specific MRN formats, in registration order
+	mrnFormats []facilityFormat
+
+	// shorteners matches links through link shortener hosts, or is nil
+	shorteners *regexp.Regexp
+
+	// corroborations are the keywords matches of a type need nearby
+	corroborations map[Type]corroboration
+
+	// confidenceFunc calibrates pattern match confidence, or is nil
+	confidenceFunc ConfidenceFunc
+
+	// Secrets for the hash, encrypt and signature modes
+	hashSalt      string
+	encryptionKey []byte
+	signatureKey  []byte
+
+	// tokenSigningKey, when set, signs the restore tokens of reversible results
+	tokenSigningKey []byte
 
 	// Configuration
-	maxTextLength int
-	defaultTTL    time.Duration
+	maxTextLength  int
+	maxTextCeiling int // Upper bound for per-request text length overrides
+	maxCustom      int // Per-request custom pattern limit
+	maxPatterns    int // Limit on engine and request patterns combined
+	defaultTTL     time.Duration
+	customPriority int // Overlap priority of request-level custom pattern matches
 }
 
 // TokenInfo stores information about a redaction token
@@ -92,16 +268,79 @@ type TokenInfo struct {
 	Type         Type      `json:"redaction_type"`
 	Created      time.Time `json:"created"`
 	Expires      time.Time `json:"expires"`
+	Span         bool      `json:"span,omitempty"` // Holds one redacted span rather than a whole document
+}
+
+// Confidence levels assigned to pattern matches
+const (
+	defaultConfidence       = 0.95 // High confidence for regex matches
+	keywordAbsentConfidence = 0.5  // Context-dependent match without its keyword
+	valueGroupConfidence    = 0.7  // Value recognised only through surrounding context
+)
+
+// contextDependentTypes lists types whose patterns capture an optional keyword
+// prefix in group 1. Matches without the keyword are far less certain.
+var contextDependentTypes = map[Type]bool{
+	TypeUKCompanyNumber:  true,
+	TypeUKPassportNumber: true,
+	TypeUSPassport:       true,
 }
 
+// requireContextOption, when true, drops matches of context-dependent types
+// found without their keyword
+const requireContextOption = "require_context"
+
+// valueGroupTypes names the capture group holding the value for types whose
+// patterns also match surrounding context. When the group participates only
+// its span is redacted, with moderate confidence.
+var valueGroupTypes = map[Type]string{
+	TypeZipCode:          "zip",
+	TypeSocialHandle:     "handle",
+	TypeBankAccount:      "account",
+	TypeSecretAssignment: "value",
+}
+
+// optInTypes are detected only when listed in Request.Types, because their
+// patterns carry a high false-positive risk
+var optInTypes = map[Type]bool{
+	TypeSocialHandle:   true,
+	TypeCurrencyAmount: true,
+}
+
+// requestsType reports whether a request explicitly lists a type
+func requestsType(request *Request, redactionType Type) bool {
+	for _, requested := range request.Types {
+		if requested == redactionType {
+			return true
+		}
+	}
+	return false
+}
+
+// Validator performs an additional check on a raw pattern match. Matches for
+// which the validator returns false are discarded.
+type Validator func(match string) bool
+
 // NewEngine creates a new redaction engine
 func NewEngine() *Engine {
 	engine := &Engine{
-		patterns:      make(map[Type]*regexp.Regexp),
-		tokens:        make(map[string]TokenInfo),
-		maxTextLength: 1024 * 1024, // 1MB default
-		defaultTTL:    24 * time.Hour,
-		mutex:         sync.RWMutex{},
+		patterns:       make(map[Type]*regexp.Regexp),
+		validators:     make(map[Type]Validator),
+		tokens:         make(map[string]TokenInfo),
+		matchCounts:    make(map[Type]int),
+		maxTextLength:  1024 * 1024, // 1MB default
+		maxTextCeiling: 1024 * 1024,
+		maxCustom:      defaultMaxCustomPatterns,
+		maxPatterns:    defaultMaxTotalPatterns,
+		defaultTTL:     24 * time.Hour,
+		mutex:          sync.RWMutex{},
+
+		anchoredPatterns: make(map[string]*regexp.Regexp),
+		policyCache:      make(map[string]*compiledPolicyRules),
+		replacements:     make(map[Type]string),
+		corroborations:   make(map[Type]corroboration),
+		customPriority:   defaultTypePriority,
+		strategyRegistry: strategies.NewDefaultStrategyRegistry(),
 	}
 
 	// Initialize default patterns
@@ -113,11 +352,23 @@ func NewEngine() *Engine {
 // NewEngineWithConfig creates a new redaction engine with custom configuration
 func NewEngineWithConfig(maxTextLength int, defaultTTL time.Duration) *Engine {
 	engine := &Engine{
-		patterns:      make(map[Type]*regexp.Regexp),
-		tokens:        make(map[string]TokenInfo),
-		maxTextLength: maxTextLength,
-		defaultTTL:    defaultTTL,
-		mutex:         sync.RWMutex{},
+		patterns:       make(map[Type]*regexp.Regexp),
+		validators:     make(map[Type]Validator),
+		tokens:         make(map[string]TokenInfo),
+		matchCounts:    make(map[Type]int),
+		maxTextLength:  maxTextLength,
+		maxTextCeiling: maxTextLength,
+		maxCustom:      defaultMaxCustomPatterns,
+		maxPatterns:    defaultMaxTotalPatterns,
+		defaultTTL:     defaultTTL,
+		mutex:          sync.RWMutex{},
+
+		anchoredPatterns: make(map[string]*regexp.Regexp),
+		policyCache:      make(map[string]*compiledPolicyRules),
+		replacements:     make(map[Type]string),
+		corroborations:   make(map[Type]corroboration),
+		customPriority:   defaultTypePriority,
+		strategyRegistry: strategies.NewDefaultStrategyRegistry(),
 	}
 
 	// Initialize default patterns
@@ -129,10 +380,12 @@ func NewEngineWithConfig(maxTextLength int, defaultTTL time.Duration) *Engine {
 // initDefaultPatterns initializes the default detection patterns
 func (re *Engine) initDefaultPatterns() {
 	// Email patterns
-	re.patterns[TypeEmail] = regexp.MustCompile(`(?i)\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Z|a-z]{2,}\b`)
+	re.patterns[TypeEmail] = regexp.MustCompile(`(?i)[\p{L}\p{N}._%+-]+@[A-Za-z0-9.-]+\.[A-Z|a-z]{2,}\b`)
 
-	// Phone number patterns (US format) - with word boundaries to avoid GUID conflicts
-	re.patterns[TypePhone] = regexp.MustCompile(`\b(\+?1[-.\s]?)?\(?([0-9]{3})\)?[-.\s]?([0-9]{3})[-.\s]?([0-9]{4})\b`)
+	// Phone number patterns (US format) - with word boundaries to avoid GUID conflicts.
+	// A trailing extension is only included when introduced by ext/extension/x.
+	re.patterns[TypePhone] = regexp.MustCompile(`\b(\+?1[-.\s]?)?\(?([0-9]{3})\)?[-.\s]?([0-9]{3})[-.\s]?([0-9]{4})` +
+		`(?:\s*(?i:ext\.?|extension|x)\s*[0-9]{1,5})?\b`)
 
 	// Credit card patterns - simplified pattern for testing
 	re.patterns[TypeCreditCard] = regexp.MustCompile(`\b\d{4}[-\s]?\d{4}[-\s]?\d{4}[-\s]?\d{4}\b`)
@@ -140,6 +393,11 @@ func (re *Engine) initDefaultPatterns() {
 	// SSN patterns (US format) - more specific to avoid ZIP+4 conflicts
 	re.patterns[TypeSSN] = regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`)
 
+	// ITIN patterns (US format) - SSN-shaped with a leading 9 and a restricted
+	// group range, outranking the SSN matcher on the same span
+	re.patterns[TypeITIN] = regexp.MustCompile(`\b9\d{2}-\d{2}-\d{4}\b`)
+	re.validators[TypeITIN] = isITIN
+
 	// IP address patterns (IPv4)
 	re.patterns[TypeIPAddress] = regexp.MustCompile(`\b(?:[0-9]{1,3}\.){3}[0-9]{1,3}\b`)
 
@@ -151,9 +409,14 @@ func (re *Engine) initDefaultPatterns() {
 
 	// Link patterns (URLs)
 	re.patterns[TypeLink] = regexp.MustCompile(`\b(?:https?://|www\.)[^\s<>"{}|\\^` + "`" + `\[\]]+`)
+	re.shorteners = shortenerPattern(defaultShortenerHosts)
 
-	// ZIP code patterns (US format) - more specific to avoid SSN conflicts
-	re.patterns[TypeZipCode] = regexp.MustCompile(`\b\d{5}-\d{4}\b`)
+	// ZIP code patterns (US format) - ZIP+4 on its own, 5-digit ZIPs only after
+	// a state abbreviation or ZIP/postal keyword (captured in the "zip" group)
+	re.patterns[TypeZipCode] = regexp.MustCompile(`\b\d{5}-\d{4}\b|` +
+		`(?:\b(?:AL|AK|AZ|AR|CA|CO|CT|DC|DE|FL|GA|HI|IA|ID|IL|IN|KS|KY|LA|MA|MD|ME|MI|MN|MO|MS|MT|` +
+		`NC|ND|NE|NH|NJ|NM|NV|NY|OH|OK|OR|PA|RI|SC|SD|TN|TX|UT|VA|VT|WA|WI|WV|WY)\s+|` +
+		`(?i:\b(?:zip|postal)(?:\s*code)?\s*:?\s*))(?P<zip>\d{5})\b`)
 
 	// PO Box patterns
 	re.patterns[TypePoBox] = regexp.MustCompile(`\b(?:P\.?O\.?\s*Box|Post\s*Office\s*Box|PO\s*Box)\s+\d+\b`)
@@ -187,8 +450,75 @@ func (re *Engine) initDefaultPatterns() {
 	re.patterns[TypeGitRepo] = regexp.MustCompile(
 		`\b(?:git@|https?://)(?:[a-zA-Z0-9-]+\.)+[a-zA-Z]{2,}(?:/[a-zA-Z0-9_.-]+)*\.git\b`)
 
+	// JWT patterns: three base64url segments (header.payload.signature) with
+	// minimum lengths so arbitrary dotted tokens are not matched
+	re.patterns[TypeJWT] = regexp.MustCompile(`\b[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{2,}\.[A-Za-z0-9_-]{16,}`)
+	re.validators[TypeJWT] = isJWT
+
+	// Webhook URL patterns: Slack and Discord incoming webhooks, and callback
+	// URLs carrying a token query parameter. These embed their credential in
+	// the URL, so they take priority over TypeLink.
+	urlChar := `[^\s<>"{}|\\^` + "`" + `\[\]]`
+	re.patterns[TypeWebhookURL] = regexp.MustCompile(
+		`\b(?:https?://)?(?:hooks\.slack\.com/services/|(?:(?:ptb|canary)\.)?discord(?:app)?\.com/api/webhooks/)` +
+			urlChar + `+|\bhttps?://` + urlChar + `+?[?&](?i:token|access_token)=` + urlChar + `+`)
+
+	// Social media handles and profile URLs (opt-in, see optInTypes). A handle
+	// must start the text or follow whitespace or punctuation, so email local
+	// parts are not matched; only the "handle" group is redacted.
+	re.patterns[TypeSocialHandle] = regexp.MustCompile(
+		`(?:^|[\s(\[{,;:!?"'])(?P<handle>@[A-Za-z0-9_]{2,30})\b|` +
+			`(?i)\b(?:https?://)?(?:www\.)?(?:twitter\.com|x\.com|instagram\.com|linkedin\.com/(?:in|company))` +
+			`/[A-Za-z0-9_.-]{2,100}/?`)
+	re.validators[TypeSocialHandle] = isSocialHandle
+
+	// US bank routing numbers (ABA): nine digits with a valid checksum
+	re.patterns[TypeRoutingNumber] = regexp.MustCompile(`\b\d{9}\b`)
+	re.validators[TypeRoutingNumber] = isABARoutingNumber
+
+	// US bank account numbers: 6-17 digits, only after an account keyword;
+	// only the "account" group is redacted
+	re.patterns[TypeBankAccount] = regexp.MustCompile(
+		`(?i)\b(?:bank\s+)?(?:account|acct)\.?(?:\s*(?:number|num|no\.?|#))?\s*:?\s*(?P<account>\d{6,17})\b`)
+
+	// Latitude/longitude pairs, redacted as one span: signed decimal degrees
+	// with at least three decimal places, so prices and version numbers are
+	// not taken for coordinates, or degrees (with optional minutes and
+	// seconds) followed by a hemisphere letter. Ranges are checked by
+	// isGeoCoordinate.
+	geoDegrees := `\d+(?:\.\d+)?\s?°(?:\s?\d+(?:\.\d+)?\s?['′])?(?:\s?\d+(?:\.\d+)?\s?(?:"|″|''))?\s?`
+	re.patterns[TypeGeoCoordinate] = regexp.MustCompile(
+		`[-+]?\b\d+\.\d{3,}°?\s*,\s*[-+]?\d+\.\d{3,}\b°?|` +
+			`\b` + geoDegrees + `[NS]\b,?\s*` + geoDegrees + `[EW]\b`)
+	re.validators[TypeGeoCoordinate] = isGeoCoordinate
+
+	// Currency amounts (opt-in, see optInTypes): a $, £ or € sign followed by
+	// digits, with optional thousands separators and decimals. Only amounts
+	// with a currency sign match, so bare numbers are left alone.
+	re.patterns[TypeCurrencyAmount] = regexp.MustCompile(
+		`[$£€]\s?(?:\d{1,3}(?:,\d{3})+|\d+)(?:\.\d{1,2})?\b`)
+
+	// PEM and OpenSSH private key blocks, redacted whole. The body cannot
+	// contain "--", so it matches across lines without (?s) and a match stops
+	// at the first END line even under leftmost-longest combined scanning.
+	re.patterns[TypePrivateKey] = regexp.MustCompile(
+		`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY-----(?:[^-]|-[^-])*-----END (?:[A-Z0-9]+ )*PRIVATE KEY-----`)
+
+	// Secret assignments in config dumps and env files; only the "value"
+	// group is redacted so the key stays visible
+	re.patterns[TypeSecretAssignment] = secretAssignmentPattern(defaultSensitiveKeys)
+
 	// Initialize UK-specific patterns
 	re.initUKPatterns()
+
+	// Initialize US-specific patterns
+	re.initUSPatterns()
+
+	// Medical record numbers, keyword-guarded until facility formats are added
+	re.patterns[TypeMRN] = mrnPattern(nil)
+
+	// Initialize vehicle identifier patterns
+	re.initVehiclePatterns()
 }
 
 // initUKPatterns initializes UK-specific detection patterns
@@ -222,36 +552,393 @@ func (re *Engine) initUKPatterns() {
 	re.patterns[TypeUKIBAN] = regexp.MustCompile(`(?i)\bGB\d{2}\s?[A-Z]{4}\s?\d{4}\s?\d{4}\s?\d{4}\s?\d{2}\b`)
 
 	// UK Company Number: 8 digits assigned by Companies House
-	// Format: 12345678 (context-dependent, so we'll be conservative)
-	re.patterns[TypeUKCompanyNumber] = regexp.MustCompile(`(?i)\b(?:Company\s+(?:No\.?|Number)\s*:?\s*)?\d{8}\b`)
+	// Format: 12345678 (context-dependent, the keyword is captured in group 1)
+	re.patterns[TypeUKCompanyNumber] = regexp.MustCompile(`(?i)\b(Company\s+(?:No\.?|Number)\s*:?\s*)?\d{8}\b`)
 
 	// UK Driving License Number: Complex format with letters and numbers
 	// Format: MORGA657054SM9IJ (5 letters, 6 digits, 2 letters, 1 digit, 2 letters)
 	re.patterns[TypeUKDrivingLicense] = regexp.MustCompile(`(?i)\b[A-Z]{5}\d{6}[A-Z]{2}\d[A-Z]{2}\b`)
 
 	// UK Passport Number: 9 digits
-	// Format: 123456789 (context-dependent, so we'll be conservative)
-	re.patterns[TypeUKPassportNumber] = regexp.MustCompile(`(?i)\b(?:Passport\s+(?:No\.?|Number)\s*:?\s*)?\d{9}\b`)
+	// Format: 123456789 (context-dependent, the keyword is captured in group 1)
+	re.patterns[TypeUKPassportNumber] = regexp.MustCompile(`(?i)\b(Passport\s+(?:No\.?|Number)\s*:?\s*)?\d{9}\b`)
+}
+
+// initUSPatterns initializes US-specific detection patterns
+func (re *Engine) initUSPatterns() {
+	// US Passport Number: 9 digits (passport book) or 1 letter and 8 digits
+	// (next generation passport)
+	// Format: A12345678 (context-dependent, the keyword is captured in group 1;
+	// all-digit numbers also need the keyword, see isUSPassport)
+	re.patterns[TypeUSPassport] = regexp.MustCompile(
+		`(?i)\b((?:U\.?S\.?\s+|United\s+States\s+)?Passport\s+(?:No\.?|Number|#)\s*:?\s*)?(?:[A-Z]\d{8}|\d{9})\b`)
+	re.validators[TypeUSPassport] = isUSPassport
+
+	// DoD ID Number (EDIPI): 10 digits, only after a DoD ID, EDIPI or
+	// military ID keyword, which is redacted with the number
+	// Format: DoD ID: 1234567890
+	re.patterns[TypeMilitaryID] = regexp.MustCompile(
+		`(?i)\b(?:DoD\s+ID|EDIPI|Military\s+ID)(?:\s+(?:No\.?|Number|#))?\s*:?\s*\d{10}\b`)
+}
+
+// isJWT confirms that the first segment of a match decodes to a JOSE header
+// (a JSON object carrying an "alg" member)
+func isJWT(match string) bool {
+	header, _, found := strings.Cut(match, ".")
+	if !found {
+		return false
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(header, "="))
+	if err != nil {
+		return false
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(decoded, &fields); err != nil {
+		return false
+	}
+
+	_, hasAlg := fields["alg"].(string)
+	return hasAlg
+}
+
+// codeAnnotations are @-prefixed words common in source code and docs that
+// must not be taken for social media handles
+var codeAnnotations = map[string]bool{
+	"override": true, "deprecated": true, "suppresswarnings": true, "functionalinterface": true,
+	"safevarargs": true, "test": true, "before": true, "after": true, "beforeeach": true,
+	"aftereach": true, "autowired": true, "component": true, "service": true, "repository": true,
+	"controller": true, "entity": true, "bean": true, "inject": true, "param": true,
+	"return": true, "returns": true, "throws": true, "see": true, "since": true, "author": true,
+	"interface": true, "property": true, "staticmethod": true, "classmethod": true,
+	"dataclass": true, "media": true, "import": true, "keyframes": true, "nullable": true,
+	"nonnull": true, "transactional": true, "todo": true, "everyone": true, "here": true,
+	"channel": true,
+}
+
+// isSocialHandle rejects code annotations and broadcast mentions. The match
+// may include the character preceding the handle.
+func isSocialHandle(match string) bool {
+	at := strings.Index(match, "@")
+	if at < 0 {
+		return true // Profile URL
+	}
+	return !codeAnnotations[strings.ToLower(match[at+1:])]
+}
+
+// defaultSensitiveKeys are the key names whose assigned values are redacted
+// as TypeSecretAssignment
+var defaultSensitiveKeys = []string{"password", "secret", "token", "api_key", "private_key"}
+
+// secretAssignmentPattern matches KEY=value and KEY: value pairs where one of
+// the underscore, dot or dash separated parts of KEY is a sensitive key, so
+// SECRET_KEY and db.password match but DEBUG does not. Keys are matched
+// case-insensitively and the value, without quotes, is in the "value" group.
+// As "password: and" is common in prose, the colon form needs the key quoted
+// (JSON) or at the start of a line (YAML), a quoted value, or no space
+// before the value.
+func secretAssignmentPattern(keys []string) *regexp.Regexp {
+	quoted := make([]string, len(keys))
+	for i, key := range keys {
+		quoted[i] = regexp.QuoteMeta(key)
+	}
+	key := `(?:[A-Za-z0-9]+[_.-])*(?:` + strings.Join(quoted, "|") + `)(?:[_.-][A-Za-z0-9]+)*`
+
+	assignments := []string{
+		`\b` + key + `["']?\s*=\s*["']?`,             // KEY=value, KEY = "value"
+		`["']` + key + `["']\s*:\s*["']?`,            // "key": "value"
+		`(?m:^)[ \t-]*` + key + `[ \t]*:[ \t]*["']?`, // key: value at the start of a line
+		`\b` + key + `\s*:\s*["']`,                   // key: "value"
+		`\b` + key + `:`,                             // key:value
+	}
+	return regexp.MustCompile(`(?i)(?:` + strings.Join(assignments, "|") + `)(?P<value>[^\s"',;]+)`)
+}
+
+// SetSensitiveKeys replaces the key names whose assigned values are redacted
+// as TypeSecretAssignment (default: password, secret, token, api_key,
+// private_key)
+func (re *Engine) SetSensitiveKeys(keys []string) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("at least one sensitive key is required")
+	}
+	for _, key := range keys {
+		if strings.TrimSpace(key) == "" {
+			return fmt.Errorf("sensitive keys cannot be empty")
+		}
+	}
+
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.patterns[TypeSecretAssignment] = secretAssignmentPattern(keys)
+	re.invalidateCombinedScan()
+	return nil
+}
+
+// isABARoutingNumber checks a nine-digit routing number has a Federal Reserve
+// prefix (00-12, 21-32, 61-72 or 80) and a valid ABA checksum: the digits
+// weighted 3, 7, 1 repeating sum to a multiple of 10
+func isABARoutingNumber(match string) bool {
+	if len(match) != 9 {
+		return false
+	}
+
+	prefix := int(match[0]-'0')*10 + int(match[1]-'0')
+	switch {
+	case prefix <= 12, prefix >= 21 && prefix <= 32, prefix >= 61 && prefix <= 72, prefix == 80:
+	default:
+		return false
+	}
+
+	weights := [3]int{3, 7, 1}
+	sum := 0
+	for i := 0; i < len(match); i++ {
+		sum += int(match[i]-'0') * weights[i%3]
+	}
+	return sum%10 == 0
+}
+
+// geoComponentPattern splits a coordinate pair into its latitude and
+// longitude: sign, degrees, minutes, seconds and hemisphere
+var geoComponentPattern = regexp.MustCompile(
+	`([-+]?)(\d+(?:\.\d+)?)\s?°?(?:\s?(\d+(?:\.\d+)?)\s?['′])?(?:\s?(\d+(?:\.\d+)?)\s?(?:"|″|''))?\s?([NSEW])?`)
+
+// isUSPassport rejects bare nine-digit numbers, which are US passport
+// numbers only when a passport keyword precedes them
+func isUSPassport(match string) bool {
+	return len(match) > 9 || match[0] < '0' || match[0] > '9'
+}
+
+// isGeoCoordinate checks a coordinate pair has a latitude within ±90 and a
+// longitude within ±180 degrees, minutes and seconds below 60, and
+// hemispheres in latitude, longitude order
+func isGeoCoordinate(match string) bool {
+	components := geoComponentPattern.FindAllStringSubmatch(match, -1)
+	if len(components) != 2 {
+		return false
+	}
+	return geoComponentInRange(components[0], 90, "NS") && geoComponentInRange(components[1], 180, "EW")
+}
+
+// geoComponentInRange checks one parsed coordinate component against its
+// limit in degrees and its allowed hemisphere letters
+func geoComponentInRange(component []string, limit float64, hemispheres string) bool {
+	degrees, err := strconv.ParseFloat(component[2], 64)
+	if err != nil {
+		return false
+	}
+
+	divisor := 1.0
+	for _, part := range component[3:5] {
+		divisor *= 60
+		if part == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(part, 64)
+		if err != nil || value >= 60 {
+			return false
+		}
+		degrees += value / divisor
+	}
+
+	if component[5] != "" && !strings.Contains(hemispheres, component[5]) {
+		return false
+	}
+	return degrees <= limit
+}
+
+// isITIN checks the group digits of an ITIN fall in the ranges the IRS
+// issues: 50-65, 70-88, 90-92 and 94-99
+func isITIN(match string) bool {
+	if len(match) != 11 || match[0] != '9' {
+		return false
+	}
+
+	group := int(match[4]-'0')*10 + int(match[5]-'0')
+	switch {
+	case group >= 50 && group <= 65,
+		group >= 70 && group <= 88,
+		group >= 90 && group <= 92,
+		group >= 94 && group <= 99:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRFC4122UUID checks a GUID's version nibble is 1-5 and its variant
+// nibble is 8, 9, a or b
+func isRFC4122UUID(match string) bool {
+	if len(match) != 36 {
+		return false
+	}
+	version := match[14]
+	variant := match[19] | 0x20 // Lower-case the hex letter
+	return version >= '1' && version <= '5' &&
+		(variant == '8' || variant == '9' || variant == 'a' || variant == 'b')
+}
+
+// SetValidator sets the validator applied to matches of the given type.
+// Passing nil removes any validator so that every pattern match is accepted.
+func (re *Engine) SetValidator(redactionType Type, validator Validator) {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	defer re.invalidateCombinedScan()
+
+	if validator == nil {
+		delete(re.validators, redactionType)
+		return
+	}
+	re.validators[redactionType] = validator
+}
+
+// SetStrictUUIDs controls whether TypeGUID matches any 8-4-4-4-12 hex value
+// (the default) or only RFC 4122 UUIDs, with a version of 1-5 and the RFC
+// 4122 variant, leaving the nil UUID and other hex-dashed tokens alone. It
+// replaces any validator set for TypeGUID with SetValidator.
+func (re *Engine) SetStrictUUIDs(strict bool) {
+	if strict {
+		re.SetValidator(TypeGUID, isRFC4122UUID)
+		return
+	}
+	re.SetValidator(TypeGUID, nil)
+}
+
+// SetStrategyRegistry sets the registry used to resolve the replacement
+// strategies named by policy rules
+func (re *Engine) SetStrategyRegistry(registry strategies.StrategyRegistry) {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.strategyRegistry = registry
+}
+
+// StrategyRegistry returns the registry the engine resolves replacement
+// strategies from
+func (re *Engine) StrategyRegistry() strategies.StrategyRegistry {
+	re.mutex.RLock()
+	defer re.mutex.RUnlock()
+
+	return re.strategyRegistry
+}
+
+// ErrInvalidPattern is returned when a user-supplied pattern is malformed or
+// too large to compile safely
+var ErrInvalidPattern = errors.New("invalid regex pattern")
+
+// ErrNotReversible is returned when restoring with an empty token, as left on
+// results of requests that were not reversible
+var ErrNotReversible = errors.New("result is not reversible: no token to restore")
+
+// ErrTooManyPatterns is returned for requests whose custom patterns exceed
+// the engine's per-request or total pattern limit
+var ErrTooManyPatterns = errors.New("too many patterns")
+
+// Default pattern limits, see SetPatternLimits
+const (
+	defaultMaxCustomPatterns = 100
+	defaultMaxTotalPatterns  = 500
+)
+
+// maxUserPatternLength caps the source length of user-supplied patterns so a
+// pathological pattern cannot exhaust memory while it compiles
+const maxUserPatternLength = 16 * 1024
+
+// compileUserPattern compiles a user-supplied pattern, returning an error
+// rather than panicking however malformed or large the pattern is
+func compileUserPattern(pattern string) (compiled *regexp.Regexp, err error) {
+	if len(pattern) > maxUserPatternLength {
+		return nil, fmt.Errorf("%w: %d bytes exceeds the %d byte limit", ErrInvalidPattern, len(pattern), maxUserPatternLength)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			compiled, err = nil, fmt.Errorf("%w: %v", ErrInvalidPattern, r)
+		}
+	}()
+
+	compiled, err = regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPattern, err)
+	}
+	return compiled, nil
+}
+
+// patternSnapshot copies the type patterns under the engine lock, so callers
+// can scan them while patterns are added or recompiled concurrently
+func (re *Engine) patternSnapshot() map[Type]*regexp.Regexp {
+	re.mutex.RLock()
+	defer re.mutex.RUnlock()
+
+	patterns := make(map[Type]*regexp.Regexp, len(re.patterns))
+	for redactionType, pattern := range re.patterns {
+		patterns[redactionType] = pattern
+	}
+	return patterns
 }
 
 // AddCustomPattern adds a custom detection pattern
 func (re *Engine) AddCustomPattern(name string, pattern string) error {
-	compiled, err := regexp.Compile(pattern)
+	compiled, err := compileUserPattern(pattern)
 	if err != nil {
-		return fmt.Errorf("invalid regex pattern: %v", err)
+		return err
 	}
 
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
 	re.patterns[Type(name)] = compiled
+	re.invalidateCombinedScan()
+	return nil
+}
+
+// caseInsensitiveFlag is the inline flag that makes a whole pattern case-insensitive
+const caseInsensitiveFlag = "(?i)"
+
+// SetCaseSensitive recompiles the pattern for the given type with or without
+// the leading case-insensitive flag. Inline flags scoped to a group, such as
+// the phone extension's (?i:ext), are left as they are.
+func (re *Engine) SetCaseSensitive(redactionType Type, caseSensitive bool) error {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	pattern, exists := re.patterns[redactionType]
+	if !exists {
+		return fmt.Errorf("no pattern registered for type: %s", redactionType)
+	}
+
+	source := strings.TrimPrefix(pattern.String(), caseInsensitiveFlag)
+	if !caseSensitive {
+		source = caseInsensitiveFlag + source
+	}
+
+	compiled, err := regexp.Compile(source)
+	if err != nil {
+		return fmt.Errorf("invalid regex pattern: %v", err)
+	}
+
+	re.patterns[redactionType] = compiled
+	re.invalidateCombinedScan()
 	return nil
 }
 
 // restoreTextInternal restores redacted text using a token (internal method)
 func (re *Engine) restoreTextInternal(token string) (string, error) {
+	if token == "" {
+		return "", ErrNotReversible
+	}
+
 	re.mutex.RLock()
 	tokenInfo, exists := re.tokens[token]
 	re.mutex.RUnlock()
 
-	if !exists {
+	if !exists || time.Now().After(tokenInfo.Expires) {
+		return "", fmt.Errorf("invalid or expired token")
+	}
+	// Only whole-result tokens are signed; span tokens keep their fixed format
+	if !tokenInfo.Span && !re.validTokenSignature(token) {
 		return "", fmt.Errorf("invalid or expired token")
 	}
 
@@ -265,6 +952,7 @@ var replacementMap = map[Type]string{
 	TypePhone:               "[PHONE_REDACTED]",
 	TypeCreditCard:          "[CREDIT_CARD_REDACTED]",
 	TypeSSN:                 "[SSN_REDACTED]",
+	TypeITIN:                "[ITIN_REDACTED]",
 	TypeAddress:             "[ADDRESS_REDACTED]",
 	TypeName:                "[NAME_REDACTED]",
 	TypeIPAddress:           "[IP_ADDRESS_REDACTED]",
@@ -282,6 +970,17 @@ var replacementMap = map[Type]string{
 	TypeMACAddress:          "[MAC_ADDRESS_REDACTED]",
 	TypeIBAN:                "[IBAN_REDACTED]",
 	TypeGitRepo:             "[GIT_REPO_REDACTED]",
+	TypeJWT:                 "[JWT_REDACTED]",
+	TypeWebhookURL:          "[WEBHOOK_URL_REDACTED]",
+	TypeSocialHandle:        "[SOCIAL_HANDLE_REDACTED]",
+	TypeGeoCoordinate:       "[GEO_COORDINATE_REDACTED]",
+	TypeCurrencyAmount:      "[AMOUNT_REDACTED]",
+	TypeRoutingNumber:       "[ROUTING_NUMBER_REDACTED]",
+	TypeBankAccount:         "[BANK_ACCOUNT_REDACTED]",
+	TypeSecretAssignment:    "[SECRET_ASSIGNMENT_REDACTED]",
+	TypePrivateKey:          "[PRIVATE_KEY_REDACTED]",
+	TypeOrganization:        "[ORGANIZATION_REDACTED]",
+	TypeLocation:            "[LOCATION_REDACTED]",
 	TypeUKNationalInsurance: "[UK_NATIONAL_INSURANCE_REDACTED]",
 	TypeUKNHSNumber:         "[UK_NHS_NUMBER_REDACTED]",
 	TypeUKPostcode:          "[UK_POSTCODE_REDACTED]",
@@ -292,21 +991,168 @@ var replacementMap = map[Type]string{
 	TypeUKCompanyNumber:     "[UK_COMPANY_NUMBER_REDACTED]",
 	TypeUKDrivingLicense:    "[UK_DRIVING_LICENSE_REDACTED]",
 	TypeUKPassportNumber:    "[UK_PASSPORT_NUMBER_REDACTED]",
+	TypeUSPassport:          "[US_PASSPORT_REDACTED]",
+	TypeMilitaryID:          "[MILITARY_ID_REDACTED]",
+	TypeHighEntropy:         "[HIGH_ENTROPY_REDACTED]",
+	TypeMRN:                 "[MRN_REDACTED]",
+	TypeVIN:                 "[VIN_REDACTED]",
+	TypeLicensePlate:        "[LICENSE_PLATE_REDACTED]",
 }
 
-// generateReplacement generates a replacement string for redacted content
+// generateReplacement returns the replace mode placeholder for a type: the
+// engine's override if set, otherwise the default
 func (re *Engine) generateReplacement(redactionType Type, _ string) string {
+	re.mutex.RLock()
+	replacement, overridden := re.replacements[redactionType]
+	re.mutex.RUnlock()
+
+	if overridden {
+		return replacement
+	}
+	return DefaultReplacement(redactionType)
+}
+
+// SetReplacement overrides the placeholder this engine uses for a type in
+// replace mode. An empty replacement restores the default.
+func (re *Engine) SetReplacement(redactionType Type, replacement string) {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	if replacement == "" {
+		delete(re.replacements, redactionType)
+		return
+	}
+	re.replacements[redactionType] = replacement
+}
+
+// DefaultReplacement returns the placeholder used for a type in replace mode
+func DefaultReplacement(redactionType Type) string {
 	if replacement, exists := replacementMap[redactionType]; exists {
 		return replacement
 	}
 	return "[REDACTED]"
 }
 
+// IsContextDependent reports whether matches of a type rely on surrounding
+// keywords or context for their confidence
+func IsContextDependent(redactionType Type) bool {
+	_, hasValueGroup := valueGroupTypes[redactionType]
+	return contextDependentTypes[redactionType] || hasValueGroup
+}
+
+// applyReplacement sets the replacement for a redaction. A request's
+// ReplacerFunc takes precedence, then its Strategy, then its Mode. A failing
+// strategy leaves the type's placeholder in place and returns the error.
+func (re *Engine) applyReplacement(ctx context.Context, request *Request, redaction *Redaction) error {
+	if request.ReplacerFunc != nil {
+		redaction.Replacement = request.ReplacerFunc(*redaction)
+		return nil
+	}
+	if request.Strategy != "" {
+		return re.replaceWithStrategy(ctx, request.Strategy, request, "", redaction)
+	}
+	return re.applyMode(request, redaction)
+}
+
+// replaceWithStrategy sets the replacement for a redaction using the named
+// strategy, or the registry's default for the redaction's type when the name
+// is StrategyDefault
+func (re *Engine) replaceWithStrategy(ctx context.Context, name string, request *Request, userID string, redaction *Redaction) error {
+	re.mutex.RLock()
+	registry := re.strategyRegistry
+	re.mutex.RUnlock()
+
+	var strategy strategies.ReplacementStrategy
+	var err error
+	if name == StrategyDefault {
+		strategy, err = registry.GetDefaultStrategy(string(redaction.Type))
+	} else {
+		strategy, err = registry.GetStrategy(name)
+	}
+	if err != nil {
+		return err
+	}
+
+	replacementContext := &strategies.ReplacementContext{UserID: userID}
+	if request.Context != nil {
+		replacementContext.Source = request.Context.Source
+		replacementContext.Field = request.Context.Field
+		replacementContext.Language = request.Context.Language
+	}
+
+	replaced, err := strategy.Replace(ctx, &strategies.ReplacementRequest{
+		OriginalText: redaction.Original,
+		DetectedType: string(redaction.Type),
+		Context:      replacementContext,
+		Options:      request.Options,
+	})
+	if err != nil {
+		return fmt.Errorf("strategy %s: %v", strategy.GetName(), err)
+	}
+
+	redaction.Replacement = replaced.ReplacedText
+	return nil
+}
+
+// applyMode sets the replacement for a redaction according to the request's
+// mode for its type. Modes without a dedicated implementation keep the
+// placeholder replacement.
+func (re *Engine) applyMode(request *Request, redaction *Redaction) error {
+	switch request.modeFor(redaction.Type) {
+	case ModeMask:
+		redaction.Replacement = maskValue(redaction.Original)
+	case ModeHash:
+		replacement, err := re.hashValue(identityValue(request, redaction))
+		if err != nil {
+			return err
+		}
+		redaction.Replacement = replacement
+	case ModeEncrypt:
+		replacement, err := re.encryptValue(redaction.Original)
+		if err != nil {
+			return err
+		}
+		redaction.Replacement = replacement
+	case ModeSignature:
+		replacement, err := re.signValue(redaction.Original)
+		if err != nil {
+			return err
+		}
+		redaction.Replacement = replacement
+	case ModeLabelHash:
+		replacement, err := re.labelHashValue(redaction.Type, identityValue(request, redaction))
+		if err != nil {
+			return err
+		}
+		redaction.Replacement = replacement
+	case ModeTokenize:
+		ttl := re.tokenTTL(request, *redaction)
+		if optionBool(request.Options, "preserve_length") {
+			token, err := re.storeFixedWidthToken(redaction, ttl)
+			if err != nil {
+				return err
+			}
+			redaction.Token = token
+		} else {
+			redaction.Token = re.storeSpanToken(redaction, ttl)
+		}
+		redaction.Replacement = redaction.Token
+	case ModeRemove:
+		redaction.Replacement = ""
+	}
+	return nil
+}
+
 // extractContext extracts context around the redacted content
 func (re *Engine) extractContext(text string, start, end int) string {
+	return re.extractSourceContext(stringSource(text), start, end)
+}
+
+// extractSourceContext extracts context around the redacted content of any match source
+func (re *Engine) extractSourceContext(src matchSource, start, end int) string {
 	contextStart := maxInt(0, start-20)
-	contextEnd := minInt(len(text), end+20)
-	return text[contextStart:contextEnd]
+	contextEnd := minInt(src.length(), end+20)
+	return src.slice(contextStart, contextEnd)
 }
 
 // GetRedactionStats returns statistics about redaction operations
@@ -325,9 +1171,42 @@ func (re *Engine) GetRedactionStats() map[string]interface{} {
 	}
 	stats["tokens_by_type"] = typeCounts
 
+	matchCounts := make(map[Type]int, len(re.matchCounts))
+	for redactionType, count := range re.matchCounts {
+		matchCounts[redactionType] = count
+	}
+	stats["matches_by_type"] = matchCounts
+	stats["policy_cache_hits"] = re.policyCacheHits
+	stats["policy_cache_misses"] = re.policyCacheMisses
+
 	return stats
 }
 
+// ResetStats clears the lifetime match and policy cache counters. Stored
+// tokens and cached policies are kept.
+func (re *Engine) ResetStats() {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.matchCounts = make(map[Type]int)
+	re.policyCacheHits = 0
+	re.policyCacheMisses = 0
+}
+
+// recordMatches adds redactions to the lifetime per-type match counters
+func (re *Engine) recordMatches(redactions []Redaction) {
+	if len(redactions) == 0 {
+		return
+	}
+
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	for _, redaction := range redactions {
+		re.matchCounts[redaction.Type]++
+	}
+}
+
 // CleanupExpiredTokens removes expired tokens
 func (re *Engine) CleanupExpiredTokens() int {
 	re.mutex.Lock()
@@ -346,6 +1225,25 @@ func (re *Engine) CleanupExpiredTokens() int {
 	return removed
 }
 
+// CleanupTokensOlderThan removes tokens created more than age ago, whether or
+// not they have expired, and returns how many were removed
+func (re *Engine) CleanupTokensOlderThan(age time.Duration) int {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	cutoff := time.Now().Add(-age)
+	removed := 0
+
+	for token, tokenInfo := range re.tokens {
+		if tokenInfo.Created.Before(cutoff) {
+			delete(re.tokens, token)
+			removed++
+		}
+	}
+
+	return removed
+}
+
 // RotateKeys rotates the encryption keys (placeholder implementation)
 func (re *Engine) RotateKeys() error {
 	re.mutex.Lock()
@@ -361,6 +1259,43 @@ func (re *Engine) RotateKeys() error {
 }
 
 // Helper functions
+
+// optionBool reads a boolean request option, treating missing or mistyped values as false
+func optionBool(options map[string]interface{}, key string) bool {
+	value, ok := options[key].(bool)
+	return ok && value
+}
+
+// optionInt reads an integer request option, accepting the float64 values
+// produced by JSON decoding; missing or mistyped values read as zero
+func optionInt(options map[string]interface{}, key string) int {
+	switch value := options[key].(type) {
+	case int:
+		return value
+	case int64:
+		return int(value)
+	case float64:
+		return int(value)
+	default:
+		return 0
+	}
+}
+
+// optionFloat reads a numeric request option as a float64; missing or
+// mistyped values read as zero
+func optionFloat(options map[string]interface{}, key string) float64 {
+	switch value := options[key].(type) {
+	case float64:
+		return value
+	case int:
+		return float64(value)
+	case int64:
+		return float64(value)
+	default:
+		return 0
+	}
+}
+
 func maxInt(a, b int) int {
 	if a > b {
 		return a
@@ -368,51 +1303,205 @@ func maxInt(a, b int) int {
 	return b
 }
 
-func minInt(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Interface implementation methods
+
+// RedactText implements RedactionProvider interface
+func (re *Engine) RedactText(ctx context.Context, request *Request) (*Result, error) {
+	if request == nil {
+		return nil, fmt.Errorf("redaction request cannot be nil")
+	}
+	re.runBeforeHooks(request)
+
+	if err := re.checkRequest(ctx, request, len(request.Text)); err != nil {
+		return nil, err
+	}
+
+	// Use existing redaction logic but with enhanced request handling
+	result, detectorErrs := re.redactTextInternal(ctx, request)
+	if err := checkGrowth(request, len(result.OriginalText), len(result.RedactedText)); err != nil {
+		return nil, err
+	}
+	result = re.finishResult(result, request)
+
+	re.runAfterHooks(result)
+	return result, re.detectorFailure(detectorErrs)
+}
+
+// OnBeforeRedact registers a hook that RedactText and RedactBytes call with
+// the request before validating it, so the hook may modify the request
+func (re *Engine) OnBeforeRedact(hook func(*Request)) {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.beforeHooks = append(re.beforeHooks, hook)
+}
+
+// OnAfterRedact registers a hook that RedactText and RedactBytes call with
+// the final result
+func (re *Engine) OnAfterRedact(hook func(*Result)) {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.afterHooks = append(re.afterHooks, hook)
+}
+
+// runBeforeHooks calls the before-redact hooks in registration order
+func (re *Engine) runBeforeHooks(request *Request) {
+	re.mutex.RLock()
+	hooks := re.beforeHooks
+	re.mutex.RUnlock()
+
+	for _, hook := range hooks {
+		hook(request)
+	}
+}
+
+// runAfterHooks calls the after-redact hooks in registration order
+func (re *Engine) runAfterHooks(result *Result) {
+	re.mutex.RLock()
+	hooks := re.afterHooks
+	re.mutex.RUnlock()
+
+	for _, hook := range hooks {
+		hook(result)
+	}
+}
+
+// checkRequest rejects cancelled contexts, invalid requests, input of length
+// bytes over the text length cap and requests over the pattern limits.
+// request must not be nil.
+func (re *Engine) checkRequest(ctx context.Context, request *Request, length int) error {
+	// Check for context cancellation
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	if err := re.checkTypes(request); err != nil {
+		return err
+	}
+
+	// Validate text length
+	if err := re.checkTextLength(request, length); err != nil {
+		return err
+	}
+
+	if err := re.checkPatternLimits(request); err != nil {
+		return err
+	}
+
+	// Unknown modes, and modes backed by missing secrets, fail up front
+	// rather than per match
+	return re.checkMode(request)
+}
+
+// SetMaxTextCeiling sets the absolute limit a request may raise its text
+// length cap to. It defaults to the engine's max text length, so requests can
+// only lower the cap until a higher ceiling is set.
+func (re *Engine) SetMaxTextCeiling(ceiling int) {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.maxTextCeiling = ceiling
+}
+
+// maxTextLengthFor returns the text length cap for a request. The cap is taken
+// from Request.MaxTextLength, then Options["max_text_length"], then the
+// engine's max text length. A requested cap above the engine ceiling is an error.
+func (re *Engine) maxTextLengthFor(request *Request) (int, error) {
+	re.mutex.RLock()
+	limit, ceiling := re.maxTextLength, re.maxTextCeiling
+	re.mutex.RUnlock()
+
+	requested := request.MaxTextLength
+	if requested == 0 {
+		requested = optionInt(request.Options, "max_text_length")
+	}
+	if requested <= 0 {
+		return limit, nil
+	}
+	if requested > ceiling {
+		return 0, fmt.Errorf("requested max text length %d exceeds the engine ceiling: %d", requested, ceiling)
+	}
+	return requested, nil
+}
+
+// checkTextLength rejects input longer than the request's text length cap
+func (re *Engine) checkTextLength(request *Request, length int) error {
+	limit, err := re.maxTextLengthFor(request)
+	if err != nil {
+		return err
+	}
+	if length > limit {
+		return fmt.Errorf("text length exceeds maximum allowed size: %d", limit)
+	}
+	return nil
+}
+
+// SetPatternLimits sets the most custom patterns a request may carry and the
+// most patterns a request may run, counting the engine's patterns and the
+// request's custom patterns. A limit of zero or less removes it. The defaults
+// are 100 and 500.
+func (re *Engine) SetPatternLimits(maxCustom, maxTotal int) {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.maxCustom = maxCustom
+	re.maxPatterns = maxTotal
 }
 
-// Interface implementation methods
+// checkPatternLimits rejects requests exceeding the engine's pattern limits
+func (re *Engine) checkPatternLimits(request *Request) error {
+	re.mutex.RLock()
+	maxCustom, maxTotal, engineCount := re.maxCustom, re.maxPatterns, len(re.patterns)
+	re.mutex.RUnlock()
 
-// RedactText implements RedactionProvider interface
-func (re *Engine) RedactText(ctx context.Context, request *Request) (*Result, error) {
-	// Check for context cancellation
-	select {
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	default:
+	custom := len(request.CustomPatterns)
+	if maxCustom > 0 && custom > maxCustom {
+		return fmt.Errorf("%w: %d custom patterns exceeds the limit of %d", ErrTooManyPatterns, custom, maxCustom)
 	}
-
-	if request == nil {
-		return nil, fmt.Errorf("redaction request cannot be nil")
+	if total := engineCount + custom; maxTotal > 0 && total > maxTotal {
+		return fmt.Errorf("%w: %d patterns exceeds the limit of %d", ErrTooManyPatterns, total, maxTotal)
 	}
+	return nil
+}
 
-	// Validate text length
-	if len(request.Text) > re.maxTextLength {
-		return nil, fmt.Errorf("text length exceeds maximum allowed size: %d", re.maxTextLength)
-	}
+// finishResult fills in rune offsets and compliance frameworks, attaches the
+// reverse mapping when requested and issues the restoration token for
+// reversible requests
+func (re *Engine) finishResult(result *Result, request *Request) *Result {
+	setGrowth(result, len(result.OriginalText), len(result.RedactedText))
+	setRuneOffsets(result.OriginalText, result.Redactions, result.Candidates)
+	return re.finishRedactions(result, request)
+}
 
-	// Use existing redaction logic but with enhanced request handling
-	result := re.redactTextInternal(request.Text)
+// finishRedactions attributes frameworks, builds the mapping and mints the
+// restore token of a result whose redactions are final
+func (re *Engine) finishRedactions(result *Result, request *Request) *Result {
+	attributeFrameworks(request, result.Redactions)
 
-	// Apply custom patterns if provided
-	if len(request.CustomPatterns) > 0 {
-		result = re.applyCustomPatterns(result, request.CustomPatterns)
+	if request.IncludeMapping {
+		result.Mapping = buildMapping(result.Redactions)
 	}
 
 	// Handle TTL for tokens
 	if request.Reversible && len(result.Redactions) > 0 {
-		ttl := request.TTL
-		if ttl == 0 {
-			ttl = re.defaultTTL
-		}
-		result.Token = re.generateTokenWithTTL(result, ttl)
+		result.Token = re.generateTokenWithTTL(result, re.tokenTTL(request, result.Redactions...))
 	}
 
-	return result, nil
+	return result
 }
 
 // RestoreText implements RedactionProvider interface
@@ -432,8 +1521,9 @@ func (re *Engine) RestoreText(_ context.Context, token string) (*RestoreResult,
 
 // GetCapabilities implements RedactionProvider interface
 func (re *Engine) GetCapabilities() *EngineCapabilities {
-	supportedTypes := make([]Type, 0, len(re.patterns))
-	for redactionType := range re.patterns {
+	patterns := re.patternSnapshot()
+	supportedTypes := make([]Type, 0, len(patterns))
+	for redactionType := range patterns {
 		supportedTypes = append(supportedTypes, redactionType)
 	}
 
@@ -441,7 +1531,8 @@ func (re *Engine) GetCapabilities() *EngineCapabilities {
 		Name:               "Engine",
 		Version:            "1.0.0",
 		SupportedTypes:     supportedTypes,
-		SupportedModes:     []Mode{ModeReplace, ModeMask, ModeRemove, ModeTokenize, ModeHash, ModeEncrypt},
+		SupportedModes:     append([]Mode(nil), validModes...),
+		ImplementedModes:   append([]Mode(nil), implementedModes...),
 		SupportsReversible: true,
 		SupportsCustom:     true,
 		SupportsLLM:        false,
@@ -473,22 +1564,32 @@ func (re *Engine) Cleanup() error {
 
 // PolicyAwareEngine interface implementation
 
-// ApplyPolicyRules applies policy-defined redaction rules
+// ApplyPolicyRules applies policy-defined redaction rules. Rule matches are
+// redacted alongside the built-in detections and take precedence over any
+// built-in match they overlap; among rules, higher priority wins.
 func (re *Engine) ApplyPolicyRules(ctx context.Context, request *PolicyRequest) (*Result, error) {
 	if request == nil || request.Request == nil {
 		return nil, fmt.Errorf("policy request cannot be nil")
 	}
 
-	// Apply the basic redaction first
-	result, err := re.RedactText(ctx, request.Request)
-	if err != nil {
+	if err := re.checkRequest(ctx, request.Request, len(request.Request.Text)); err != nil {
 		return nil, err
 	}
 
-	// Apply policy rules to enhance/modify the result
-	// This is a simplified implementation - policy rules are evaluated
-	// to determine which patterns to apply with what priority
-	for _, rule := range request.PolicyRules {
+	text := request.Text
+	builtin, detectorErrs := re.collectRedactions(ctx, stringSource(text), request.Request)
+
+	// Policy redactions accumulate in their own result so they can be told
+	// apart from the built-in detections they override
+	policyResult := &Result{
+		OriginalText: text,
+		Redactions:   []Redaction{},
+		Errors:       errorStrings(detectorErrs),
+	}
+
+	rules := sortRulesByPriority(request.PolicyRules)
+	compiled := re.compilePolicyRules(rules)
+	for i, rule := range rules {
 		if !rule.Enabled {
 			continue
 		}
@@ -498,17 +1599,133 @@ func (re *Engine) ApplyPolicyRules(ctx context.Context, request *PolicyRequest)
 			continue
 		}
 
-		// Apply the rule patterns (simplified implementation)
-		// In a full implementation, this would integrate more deeply
-		// with the pattern matching and redaction process
-		_ = rule // Rule processing placeholder
+		for _, pattern := range compiled.patterns[i] {
+			if pattern.err != nil {
+				return nil, fmt.Errorf("rule %s: %w", rule.Name, pattern.err)
+			}
+
+			re.applyPatternToResult(ctx, policyResult, pattern.regexp, rule, request, builtin)
+		}
+	}
+
+	// Built-in detections overlapped by a rule match are superseded
+	result := &Result{
+		OriginalText: text,
+		Redactions:   policyResult.Redactions,
+		Timestamp:    time.Now(),
+		Errors:       policyResult.Errors,
+	}
+	for _, redaction := range builtin {
+		if re.overlapsAny(redaction, policyResult.Redactions) {
+			continue
+		}
+		if err := re.applyReplacement(ctx, request.Request, &redaction); err != nil {
+			result.Errors = append(result.Errors, err.Error())
+		}
+		result.Redactions = append(result.Redactions, redaction)
+	}
+
+	sortRedactionsAscending(result.Redactions)
+	result.RedactedText = applyRedactionsWithOptions(text, result.Redactions, request.Request)
+	if err := checkGrowth(request.Request, len(result.OriginalText), len(result.RedactedText)); err != nil {
+		return nil, err
+	}
+
+	return re.finishResult(result, request.Request), re.detectorFailure(detectorErrs)
+}
+
+// applyPatternToResult adds a redaction to result for every match of a rule
+// pattern that does not overlap a match of an earlier (higher priority) rule.
+// A match that overlaps a built-in detection inherits its type so strategies
+// can format the replacement. Strategy failures are recorded in result.Errors
+// and the match falls back to the type's placeholder.
+func (re *Engine) applyPatternToResult(ctx context.Context, result *Result, pattern *regexp.Regexp, rule PolicyRule, request *PolicyRequest, builtin []Redaction) {
+	for _, match := range pattern.FindAllStringIndex(result.OriginalText, -1) {
+		start, end := match[0], match[1]
+		if start == end {
+			continue
+		}
+
+		redaction := Redaction{
+			Type:       TypeCustom,
+			Start:      start,
+			End:        end,
+			Original:   result.OriginalText[start:end],
+			Confidence: defaultConfidence,
+			Context:    re.extractContext(result.OriginalText, start, end),
+		}
+		if re.overlapsAny(redaction, result.Redactions) {
+			continue
+		}
+		for _, detected := range builtin {
+			if re.redactionsOverlap(redaction, detected) {
+				redaction.Type = detected.Type
+				break
+			}
+		}
+
+		if err := re.generatePolicyReplacement(ctx, rule, request, &redaction); err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			redaction.Replacement = re.generateReplacement(redaction.Type, redaction.Original)
+		}
+		result.Redactions = append(result.Redactions, redaction)
+	}
+}
+
+// generatePolicyReplacement sets the replacement for a rule match. Rules that
+// name a strategy delegate to it; otherwise the rule's mode applies to every
+// type, falling back to the request's strategy or modes when the rule has none.
+func (re *Engine) generatePolicyReplacement(ctx context.Context, rule PolicyRule, request *PolicyRequest, redaction *Redaction) error {
+	redaction.Replacement = re.generateReplacement(redaction.Type, redaction.Original)
+
+	ruleRequest := *request.Request
+
+	var err error
+	switch {
+	case rule.Strategy != "":
+		err = re.replaceWithStrategy(ctx, rule.Strategy, &ruleRequest, request.UserID, redaction)
+	case rule.Mode != "":
+		ruleRequest.Mode = rule.Mode
+		ruleRequest.TypeModes = nil
+		ruleRequest.Strategy = ""
+		err = re.applyReplacement(ctx, &ruleRequest, redaction)
+	default:
+		err = re.applyReplacement(ctx, &ruleRequest, redaction)
+	}
+	if err != nil {
+		return fmt.Errorf("rule %s: %v", rule.Name, err)
 	}
 
-	return result, nil
+	return nil
+}
+
+// sortRulesByPriority returns the rules ordered by descending priority,
+// keeping the declared order among equal priorities
+func sortRulesByPriority(rules []PolicyRule) []PolicyRule {
+	sorted := make([]PolicyRule, len(rules))
+	copy(sorted, rules)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority > sorted[j].Priority
+	})
+	return sorted
+}
+
+// overlapsAny reports whether a redaction overlaps any of the given redactions
+func (re *Engine) overlapsAny(redaction Redaction, others []Redaction) bool {
+	for _, other := range others {
+		if re.redactionsOverlap(redaction, other) {
+			return true
+		}
+	}
+	return false
 }
 
 // ValidatePolicy validates that policy rules are compatible with this engine
 func (re *Engine) ValidatePolicy(_ context.Context, rules []PolicyRule) []ValidationError {
+	re.mutex.RLock()
+	registry := re.strategyRegistry
+	re.mutex.RUnlock()
+
 	var errors []ValidationError
 
 	for _, rule := range rules {
@@ -529,6 +1746,15 @@ func (re *Engine) ValidatePolicy(_ context.Context, rules []PolicyRule) []Valida
 				Code:    "NO_PATTERNS",
 			})
 		}
+		for _, pattern := range rule.Patterns {
+			if _, err := compileUserPattern(pattern); err != nil {
+				errors = append(errors, ValidationError{
+					Rule:    rule.Name,
+					Message: err.Error(),
+					Code:    "INVALID_PATTERN",
+				})
+			}
+		}
 
 		// Validate priority
 		if rule.Priority < 0 {
@@ -540,21 +1766,24 @@ func (re *Engine) ValidatePolicy(_ context.Context, rules []PolicyRule) []Valida
 		}
 
 		// Validate mode
-		validModes := []Mode{ModeReplace, ModeMask, ModeRemove, ModeTokenize, ModeHash, ModeEncrypt}
-		modeValid := false
-		for _, validMode := range validModes {
-			if rule.Mode == validMode {
-				modeValid = true
-				break
-			}
-		}
-		if !modeValid {
+		if !isValidMode(rule.Mode) {
 			errors = append(errors, ValidationError{
 				Rule:    rule.Name,
 				Message: fmt.Sprintf("invalid redaction mode: %s", rule.Mode),
 				Code:    "INVALID_MODE",
 			})
 		}
+
+		// Validate strategy
+		if rule.Strategy != "" && rule.Strategy != StrategyDefault {
+			if _, err := registry.GetStrategy(rule.Strategy); err != nil {
+				errors = append(errors, ValidationError{
+					Rule:    rule.Name,
+					Message: fmt.Sprintf("unknown replacement strategy: %s", rule.Strategy),
+					Code:    "INVALID_STRATEGY",
+				})
+			}
+		}
 	}
 
 	return errors
@@ -612,66 +1841,369 @@ func (re *Engine) evaluateStringCondition(fieldValue string, operator string, ex
 // Helper methods for interface implementation
 
 // redactTextInternal performs the core redaction logic (renamed from RedactText)
-func (re *Engine) redactTextInternal(text string) *Result {
-	result := &Result{
-		OriginalText: text,
-		RedactedText: text,
-		Redactions:   []Redaction{},
-		Timestamp:    time.Now(),
+// and returns the errors of any failed detectors alongside the partial result
+func (re *Engine) redactTextInternal(ctx context.Context, request *Request) (*Result, []error) {
+	redactions, candidates, errs := re.findRedactions(ctx, stringSource(request.Text), request, findOptions{limit: resolveLimit(request)})
+	return re.redactFound(ctx, request, redactions, candidates, errs)
+}
+
+// redactFound builds the result of a text request from the redactions and
+// candidates found in its text, applying the request mode to them
+func (re *Engine) redactFound(ctx context.Context, request *Request, redactions, candidates []Redaction, errs []error) (*Result, []error) {
+	text := request.Text
+	result, cut, errs := re.settleRedactions(ctx, stringSource(text), request, redactions, candidates, errs)
+	result.OriginalText = text
+
+	result.RedactedText = applyRedactionsWithOptions(text[:cut], result.Redactions, request)
+	result.RedactedText, result.EmptyOutputReplaced = fillEmptyOutput(result.RedactedText, result.Redactions, request)
+	if optionBool(request.Options, advisoriesOption) {
+		result.Advisories = findAdvisories(text[:cut], result.Redactions)
+	}
+
+	return result, errs
+}
+
+// settleRedactions applies the request mode to the redactions found in src
+// and spends the request's budget on them. It returns a result holding the
+// redactions and candidates before the cut, and the offset src is cut at;
+// building the redacted output is left to the caller.
+func (re *Engine) settleRedactions(ctx context.Context, src matchSource, request *Request, redactions, candidates []Redaction, errs []error) (*Result, int, []error) {
+	redactions, errs = re.replaceRedactions(ctx, src, request, redactions, candidates, errs, newRedactionBudget(request))
+
+	// Redactions are returned in document order
+	sortRedactionsAscending(redactions)
+	kept, cut, truncated := applyBudget(src.length(), redactions, request)
+	if truncated {
+		re.discardTokens(redactions[len(kept):])
+		candidates = candidatesBefore(candidates, cut)
+	}
+	re.recordMatches(kept)
+
+	return &Result{
+		Redactions: kept,
+		Candidates: candidates,
+		Truncated:  truncated,
+		Errors:     errorStrings(errs),
+		Timestamp:  time.Now(),
+	}, cut, errs
+}
+
+// matchSource abstracts over the string and []byte inputs so both redaction
+// paths share detection and overlap resolution
+type matchSource interface {
+	findAll(pattern *regexp.Regexp) [][]int
+	findAllSubmatch(pattern *regexp.Regexp) [][]int
+	slice(start, end int) string
+	length() int
+}
+
+// stringSource is a matchSource over a string
+type stringSource string
+
+func (s stringSource) findAll(pattern *regexp.Regexp) [][]int {
+	return pattern.FindAllStringIndex(string(s), -1)
+}
+
+func (s stringSource) findAllSubmatch(pattern *regexp.Regexp) [][]int {
+	return pattern.FindAllStringSubmatchIndex(string(s), -1)
+}
+
+func (s stringSource) slice(start, end int) string { return string(s[start:end]) }
+
+func (s stringSource) length() int { return len(s) }
+
+// detectRedactions collects all pattern and detector matches in src, resolves
+// overlaps and applies the request mode to the surviving redactions. Under the
+// "annotate" overlap policy the losing matches are returned as candidates.
+// Matches for which exclude, if set, returns true are dropped first.
+func (re *Engine) detectRedactions(ctx context.Context, src matchSource, request *Request, exclude func(Redaction) bool) ([]Redaction, []Redaction, []error) {
+	redactions, candidates, errs := re.findRedactions(ctx, src, request, findOptions{exclude: exclude})
+	redactions, errs = re.replaceRedactions(ctx, src, request, redactions, candidates, errs, nil)
+	return redactions, candidates, errs
+}
+
+// findOptions narrows findRedactions. A positive limit stops overlap
+// resolution once that many leading redactions are settled. exclude, if set,
+// drops matches before overlap resolution, so a dropped match never
+// displaces the matches it overlaps.
+type findOptions struct {
+	limit   int
+	exclude func(Redaction) bool
+}
+
+// findRedactions collects the matches in src and resolves overlaps, leaving
+// the redactions and candidates sorted but not yet replaced
+func (re *Engine) findRedactions(ctx context.Context, src matchSource, request *Request, opts findOptions) ([]Redaction, []Redaction, []error) {
+	var redactions, candidates []Redaction
+	var errs []error
+	if optionBool(request.Options, normalizeOption) || optionBool(request.Options, nfcOption) {
+		redactions, candidates, errs = re.collectNormalizedRedactions(ctx, src, request, opts)
+	} else {
+		redactions, candidates, errs = re.collectAnnotatedRedactions(ctx, src, request, opts)
+	}
+	sortRedactionsAscending(candidates)
+	sortRedactionsAscending(redactions)
+	return redactions, candidates, errs
+}
+
+// replaceRedactions applies the request mode to found redactions, merging
+// adjacent ones first when requested, and appends any replacement failures
+// to errs. Replacement stops once budget is spent; the first redaction that
+// did not fit is kept last so the caller knows where to cut the text.
+func (re *Engine) replaceRedactions(ctx context.Context, src matchSource, request *Request, redactions, candidates []Redaction, errs []error, budget *redactionBudget) ([]Redaction, []error) {
+	// Produce replacements for the surviving redactions in document order so
+	// replacer funcs see matches as they appear
+	if gap := mergeGap(request.Options); gap > 0 {
+		redactions = re.mergeAdjacent(src, redactions, gap)
+	}
+	for i := range redactions {
+		if !budget.allows(i) {
+			redactions = redactions[:i+1]
+			break
+		}
+		if err := re.applyReplacement(ctx, request, &redactions[i]); err != nil {
+			errs = append(errs, err)
+		}
+		if !budget.spend(redactions[i]) {
+			redactions = redactions[:i+1]
+			break
+		}
+	}
+	numberPlaceholders(request, redactions)
+	re.applyContextCapture(src, request, redactions, candidates)
+
+	return redactions, errs
+}
+
+// collectNormalizedRedactions detects on the normalized form of src and maps
+// the redactions back, so each span covers the raw characters it came from,
+// including any stripped zero-width characters or combining marks inside it
+func (re *Engine) collectNormalizedRedactions(ctx context.Context, src matchSource, request *Request, opts findOptions) ([]Redaction, []Redaction, []error) {
+	original := src.slice(0, src.length())
+	normalized := normalizeText(original, optionBool(request.Options, normalizeOption), optionBool(request.Options, nfcOption))
+
+	redactions, candidates, errs := re.collectAnnotatedRedactions(ctx, stringSource(normalized.text), request, opts)
+	for _, spans := range [][]Redaction{redactions, candidates} {
+		for i := range spans {
+			start, end := normalized.span(spans[i].Start, spans[i].End)
+			spans[i].Start, spans[i].End = start, end
+			spans[i].Original = original[start:end]
+			spans[i].Context = re.extractSourceContext(src, start, end)
+		}
+	}
+	return redactions, candidates, errs
+}
+
+// overlapPolicyOption selects how overlapping matches are reported:
+// "resolve" (the default) keeps only the winners, "annotate" also returns the
+// losers as candidates
+const overlapPolicyOption = "overlap_policy"
+
+// overlapPolicyAnnotate is the overlap policy that retains losing matches
+const overlapPolicyAnnotate = "annotate"
+
+// collectAnnotatedRedactions is collectRedactions that, under the "annotate"
+// overlap policy, also returns the matches that lost overlap resolution.
+// opts are as for findRedactions; matches past the point resolution stopped
+// are returned as candidates.
+func (re *Engine) collectAnnotatedRedactions(ctx context.Context, src matchSource, request *Request, opts findOptions) ([]Redaction, []Redaction, []error) {
+	all, errs := re.collectCandidates(ctx, src, request)
+	if opts.exclude != nil {
+		kept := all[:0]
+		for _, candidate := range all {
+			if !opts.exclude(candidate) {
+				kept = append(kept, candidate)
+			}
+		}
+		all = kept
+	}
+	if policy, _ := request.Options[overlapPolicyOption].(string); policy != overlapPolicyAnnotate {
+		return re.resolveCandidates(all, request.TypePriorities, opts.limit), nil, errs
+	}
+
+	redactions := re.resolveCandidates(append([]Redaction(nil), all...), request.TypePriorities, opts.limit)
+	return redactions, overlapLosers(all, redactions), errs
+}
+
+// overlapLosers returns the matches of all that are not among the winners
+func overlapLosers(all, winners []Redaction) []Redaction {
+	type span struct {
+		redactionType Type
+		start, end    int
+	}
+	won := make(map[span]int, len(winners))
+	for _, winner := range winners {
+		won[span{winner.Type, winner.Start, winner.End}]++
+	}
+
+	losers := []Redaction{}
+	for _, candidate := range all {
+		key := span{candidate.Type, candidate.Start, candidate.End}
+		if won[key] > 0 {
+			won[key]--
+			continue
+		}
+		losers = append(losers, candidate)
 	}
+	return losers
+}
+
+// collectRedactions finds all pattern and detector matches in src and resolves
+// overlaps. Matches below the request's minimum confidence are dropped before
+// overlaps are resolved so they cannot displace more certain matches. Failing
+// detectors are skipped and their errors returned.
+func (re *Engine) collectRedactions(ctx context.Context, src matchSource, request *Request) ([]Redaction, []error) {
+	candidates, errs := re.collectCandidates(ctx, src, request)
+	return re.resolveCandidates(candidates, request.TypePriorities, 0), errs
+}
+
+// scanCandidates finds all pattern and detector matches in src, before
+// overlaps are resolved. collectCandidates hides skipped regions from it.
+func (re *Engine) scanCandidates(ctx context.Context, src matchSource, request *Request) ([]Redaction, []error) {
+	// Collect all potential redactions, starting with those of plugged-in detectors
+	detected, errs := re.runDetectors(ctx, src)
 
-	// Collect all potential redactions
 	var allRedactions []Redaction
+	for _, redaction := range detected {
+		if redaction.Confidence < request.MinConfidence {
+			continue
+		}
+		redaction.Replacement = re.generateReplacement(redaction.Type, redaction.Original)
+		redaction.Context = re.extractSourceContext(src, redaction.Start, redaction.End)
+		allRedactions = append(allRedactions, redaction)
+	}
 
-	// Process each redaction type
-	for redactionType, pattern := range re.patterns {
-		matches := pattern.FindAllStringIndex(text, -1)
+	// Types covered by the combined pattern are found in a single pass
+	scan := re.combinedScanner()
+	if scan != nil {
+		allRedactions = append(allRedactions, re.collectCombined(src, scan, request)...)
+	}
+
+	requireContext, _ := request.Options[requireContextOption].(bool)
+	calibrate := re.confidenceFuncSnapshot()
+
+	// Process each remaining redaction type
+	for redactionType, pattern := range re.patternSnapshot() {
+		if optInTypes[redactionType] && !requestsType(request, redactionType) {
+			continue
+		}
+		if scan != nil && scan.types[redactionType] {
+			continue
+		}
+
+		valueGroup := 0
+		if name, exists := valueGroupTypes[redactionType]; exists {
+			valueGroup = pattern.SubexpIndex(name)
+		}
+
+		var matches [][]int
+		if contextDependentTypes[redactionType] || valueGroup > 0 {
+			matches = src.findAllSubmatch(pattern)
+		} else {
+			matches = src.findAll(pattern)
+		}
 
 		for _, match := range matches {
 			start, end := match[0], match[1]
-			original := text[start:end]
+			confidence := matchConfidence(redactionType, match)
+			if valueGroup > 0 {
+				if groupStart, groupEnd, ok := targetSpan(match, valueGroup); ok {
+					start, end = groupStart, groupEnd
+					confidence = valueGroupConfidence
+				}
+			}
+			original := src.slice(start, end)
 
-			// Create redaction
-			redaction := Redaction{
-				Type:        redactionType,
-				Start:       start,
-				End:         end,
-				Original:    original,
-				Replacement: re.generateReplacement(redactionType, original),
-				Confidence:  0.95, // High confidence for regex matches
-				Context:     re.extractContext(text, start, end),
+			if !re.isValidMatch(redactionType, original) {
+				continue
+			}
+
+			confidence = re.calibratedConfidence(calibrate, src, redactionType, start, end, confidence)
+			if confidence < request.MinConfidence {
+				continue
+			}
+			if requireContext && contextDependentTypes[redactionType] && confidence <= keywordAbsentConfidence {
+				continue
 			}
 
-			allRedactions = append(allRedactions, redaction)
+			allRedactions = append(allRedactions, re.patternRedaction(src, redactionType, start, end, confidence))
 		}
 	}
 
-	// Resolve overlapping redactions (longer match wins, then by type priority)
-	result.Redactions = re.resolveOverlappingRedactions(allRedactions)
+	allRedactions = re.collectShortenedLinks(src, allRedactions)
+	if optionBool(request.Options, emailHeadersOption) {
+		allRedactions = append(allRedactions, re.collectEmailHeaders(src, request.MinConfidence)...)
+	}
 
-	// Sort redactions by start position (descending) to apply from end to beginning
-	for i := 0; i < len(result.Redactions); i++ {
-		for j := i + 1; j < len(result.Redactions); j++ {
-			if result.Redactions[i].Start < result.Redactions[j].Start {
-				result.Redactions[i], result.Redactions[j] = result.Redactions[j], result.Redactions[i]
-			}
-		}
+	// Request-level custom patterns and literal secrets compete with the
+	// built-in matches
+	allRedactions = append(allRedactions, re.collectCustomRedactions(src, request.CustomPatterns, request.MinConfidence)...)
+	allRedactions = append(allRedactions, re.collectLiteralSecrets(src, request)...)
+
+	allRedactions = re.filterCorroborated(src, allRedactions)
+	if optionBool(request.Options, validatedOnlyOption) {
+		allRedactions = filterValidated(allRedactions)
 	}
 
-	// Apply redactions from end to beginning to maintain indices
-	for _, redaction := range result.Redactions {
-		if redaction.Start >= 0 && redaction.End <= len(result.RedactedText) {
-			result.RedactedText = result.RedactedText[:redaction.Start] +
-				redaction.Replacement +
-				result.RedactedText[redaction.End:]
-		}
+	return allRedactions, errs
+}
+
+// resolveCandidates resolves overlapping candidates (longer match wins, then
+// by type priority, with priorities overriding the defaults), never
+// returning nil
+func (re *Engine) resolveCandidates(candidates []Redaction, priorities map[Type]int, limit int) []Redaction {
+	redactions := re.resolveOverlappingRedactions(candidates, priorities, limit)
+	if redactions == nil {
+		redactions = []Redaction{}
 	}
+	return redactions
+}
 
-	return result
+// patternRedaction creates the redaction for a pattern match
+func (re *Engine) patternRedaction(src matchSource, redactionType Type, start, end int, confidence float64) Redaction {
+	original := src.slice(start, end)
+	return Redaction{
+		Type:        redactionType,
+		Start:       start,
+		End:         end,
+		Original:    original,
+		Replacement: re.generateReplacement(redactionType, original),
+		Confidence:  confidence,
+		Context:     re.extractSourceContext(src, start, end),
+	}
+}
+
+// matchConfidence returns the confidence of a match. For context-dependent
+// types the match holds submatch indices and confidence drops when the keyword
+// group did not participate.
+func matchConfidence(redactionType Type, match []int) float64 {
+	if contextDependentTypes[redactionType] && len(match) >= 4 && match[2] < 0 {
+		return keywordAbsentConfidence
+	}
+	return defaultConfidence
+}
+
+// sortRedactionsAscending orders redactions by start position, first first
+func sortRedactionsAscending(redactions []Redaction) {
+	sort.SliceStable(redactions, func(i, j int) bool {
+		return redactions[i].Start < redactions[j].Start
+	})
 }
 
-// resolveOverlappingRedactions removes overlapping redactions using conflict resolution
-func (re *Engine) resolveOverlappingRedactions(redactions []Redaction) []Redaction {
+// isValidMatch runs the validator registered for a type, if any
+func (re *Engine) isValidMatch(redactionType Type, match string) bool {
+	re.mutex.RLock()
+	validate, exists := re.validators[redactionType]
+	re.mutex.RUnlock()
+
+	return !exists || validate(match)
+}
+
+// resolveOverlappingRedactions removes overlapping redactions using conflict
+// resolution. priorities, which may be nil, override the default type
+// priorities. A positive limit stops once the first limit redactions can no
+// longer be displaced, leaving the rest unresolved.
+func (re *Engine) resolveOverlappingRedactions(redactions []Redaction, priorities map[Type]int, limit int) []Redaction {
 	if len(redactions) <= 1 {
 		return redactions
 	}
@@ -688,6 +2220,10 @@ func (re *Engine) resolveOverlappingRedactions(redactions []Redaction) []Redacti
 	var resolved []Redaction
 
 	for _, current := range redactions {
+		if limit > 0 && settled(resolved, limit, current.Start) {
+			break
+		}
+
 		overlappingIndices := []int{}
 
 		// Find all overlapping redactions
@@ -707,7 +2243,7 @@ func (re *Engine) resolveOverlappingRedactions(redactions []Redaction) []Redacti
 
 			for _, idx := range overlappingIndices {
 				existing := resolved[idx]
-				if re.shouldReplaceRedaction(current, existing) {
+				if re.shouldReplaceRedaction(current, existing, priorities) {
 					// Current wins over this existing redaction
 					indicesToRemove = append(indicesToRemove, idx)
 				} else {
@@ -732,13 +2268,27 @@ func (re *Engine) resolveOverlappingRedactions(redactions []Redaction) []Redacti
 	return resolved
 }
 
+// settled reports whether the first limit resolved redactions all end by
+// start, so no redaction starting there or later can displace them
+func settled(resolved []Redaction, limit, start int) bool {
+	if len(resolved) < limit {
+		return false
+	}
+	for _, redaction := range resolved[:limit] {
+		if redaction.End > start {
+			return false
+		}
+	}
+	return true
+}
+
 // redactionsOverlap checks if two redactions overlap
 func (re *Engine) redactionsOverlap(a, b Redaction) bool {
 	return a.Start < b.End && b.Start < a.End
 }
 
 // shouldReplaceRedaction determines if redaction 'new' should replace 'existing'
-func (re *Engine) shouldReplaceRedaction(newRedaction, existing Redaction) bool {
+func (re *Engine) shouldReplaceRedaction(newRedaction, existing Redaction, priorities map[Type]int) bool {
 	newLength := newRedaction.End - newRedaction.Start
 	existingLength := existing.End - existing.Start
 
@@ -747,77 +2297,207 @@ func (re *Engine) shouldReplaceRedaction(newRedaction, existing Redaction) bool
 		return newLength > existingLength
 	}
 
+	// A context-dependent match without its keyword is only a guess, so any
+	// other match of the same length outranks it
+	if newGuess, existingGuess := isKeywordlessGuess(newRedaction), isKeywordlessGuess(existing); newGuess != existingGuess {
+		return existingGuess
+	}
+
 	// If same length, prefer by type priority (UK-specific types have higher priority)
-	newPriority := re.getTypePriority(newRedaction.Type)
-	existingPriority := re.getTypePriority(existing.Type)
+	newPriority := re.typePriority(newRedaction.Type, priorities)
+	existingPriority := re.typePriority(existing.Type, priorities)
 
 	return newPriority > existingPriority
 }
 
+// isKeywordlessGuess reports whether a redaction is a context-dependent match
+// whose keyword was absent, such as a bare nine-digit passport number
+func isKeywordlessGuess(redaction Redaction) bool {
+	return contextDependentTypes[redaction.Type] && redaction.Confidence <= keywordAbsentConfidence
+}
+
+// defaultTypePriority is the overlap priority of types without a dedicated tier
+const defaultTypePriority = 30
+
 // getTypePriority returns priority for redaction types (higher = more important)
 func (re *Engine) getTypePriority(redactionType Type) int {
+	re.mutex.RLock()
+	defer re.mutex.RUnlock()
+
+	return re.getTypePriorityLocked(redactionType)
+}
+
+// typePriority returns the priority of a type from priorities, falling back
+// to the default priority for types not listed
+func (re *Engine) typePriority(redactionType Type, priorities map[Type]int) int {
+	if priority, exists := priorities[redactionType]; exists {
+		return priority
+	}
+	return re.getTypePriority(redactionType)
+}
+
+// getTypePriorityLocked returns the priority of a type. Callers must hold the
+// engine lock.
+func (re *Engine) getTypePriorityLocked(redactionType Type) int {
 	// UK-specific types get higher priority
 	switch redactionType {
-	case TypeUKNationalInsurance, TypeUKNHSNumber, TypeUKPassportNumber:
+	case TypeUKNationalInsurance, TypeUKNHSNumber, TypeUKPassportNumber, TypePrivateKey, TypeMRN:
 		return 100 // Very high priority
-	case TypeUKDrivingLicense, TypeUKIBAN, TypeUKSortCode:
+	case TypeUKDrivingLicense, TypeUKIBAN, TypeUKSortCode, TypeJWT, TypeWebhookURL, TypeBankAccount,
+		TypeUSPassport, TypeMilitaryID, TypeVIN:
 		return 90 // High priority
-	case TypeUKPhoneNumber, TypeUKMobileNumber, TypeUKCompanyNumber:
+	case TypeUKPhoneNumber, TypeUKMobileNumber, TypeUKCompanyNumber, TypeRoutingNumber, TypeSecretAssignment,
+		TypeLicensePlate:
 		return 80 // Medium-high priority
-	case TypeUKPostcode:
+	case TypeUKPostcode, TypeITIN:
 		return 70 // Medium priority
 	case TypeSSN, TypeCreditCard:
 		return 60 // Standard high priority
 	case TypeEmail, TypePhone:
 		return 50 // Standard medium priority
-	case TypeIPAddress, TypeDate, TypeTime:
+	case TypeIPAddress, TypeDate, TypeTime, TypeSocialHandle, TypeGeoCoordinate, TypeCurrencyAmount:
 		return 40 // Lower priority
+	case TypeCustom:
+		return re.customPriority
 	default:
-		return 30 // Default priority
+		return defaultTypePriority
 	}
 }
 
-// applyCustomPatterns applies custom patterns to the redaction result
-func (re *Engine) applyCustomPatterns(result *Result, patterns []CustomPattern) *Result {
+// SetCustomPriority sets the priority request-level custom pattern matches
+// use when they overlap a built-in match of the same length
+func (re *Engine) SetCustomPriority(priority int) {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.customPriority = priority
+}
+
+// collectCustomRedactions matches request-level custom patterns against the
+// source. The matches are resolved against the built-in detections like any
+// other, so they are never applied on top of an overlapping redaction.
+func (re *Engine) collectCustomRedactions(src matchSource, patterns []CustomPattern, minConfidence float64) []Redaction {
+	var redactions []Redaction
 	for _, pattern := range patterns {
-		compiled, err := regexp.Compile(pattern.Pattern)
+		compiled, err := compileUserPattern(pattern.Pattern)
 		if err != nil {
 			continue // Skip invalid patterns
 		}
 
-		matches := compiled.FindAllStringIndex(result.RedactedText, -1)
-		for _, match := range matches {
-			start, end := match[0], match[1]
-			original := result.RedactedText[start:end]
+		// Only the named group is redacted when one is targeted
+		groupIndex := 0
+		if pattern.Group != "" {
+			groupIndex = compiled.SubexpIndex(pattern.Group)
+			if groupIndex < 0 {
+				continue // Skip patterns without the target group
+			}
+		}
+
+		var matches [][]int
+		if groupIndex > 0 {
+			matches = src.findAllSubmatch(compiled)
+		} else {
+			matches = src.findAll(compiled)
+		}
+
+		replacement := pattern.Replacement
+		if replacement == "" {
+			replacement = "[CUSTOM_REDACTED]"
+		}
+
+		confidence := pattern.Confidence
+		if confidence == 0 {
+			confidence = defaultConfidence
+		}
+		if confidence < minConfidence {
+			continue
+		}
 
-			replacement := pattern.Replacement
-			if replacement == "" {
-				replacement = "[CUSTOM_REDACTED]"
+		for _, match := range matches {
+			start, end, ok := targetSpan(match, groupIndex)
+			if !ok {
+				continue
 			}
 
-			redaction := Redaction{
+			redactions = append(redactions, Redaction{
 				Type:        TypeCustom,
 				Start:       start,
 				End:         end,
-				Original:    original,
+				Original:    src.slice(start, end),
 				Replacement: replacement,
-				Confidence:  pattern.Confidence,
-				Context:     re.extractContext(result.RedactedText, start, end),
-			}
+				Confidence:  confidence,
+				Context:     re.extractSourceContext(src, start, end),
+			})
+		}
+	}
+
+	return redactions
+}
 
-			result.Redactions = append(result.Redactions, redaction)
+// literalSource marks the redactions of Request.LiteralSecrets
+const literalSource = "literal"
+
+// collectLiteralSecrets matches each of the request's literal secrets
+// verbatim, redacting them as TypeCustom with source "literal"
+func (re *Engine) collectLiteralSecrets(src matchSource, request *Request) []Redaction {
+	patterns := make([]CustomPattern, 0, len(request.LiteralSecrets))
+	for _, secret := range request.LiteralSecrets {
+		if secret == "" {
+			continue
 		}
+		pattern := regexp.QuoteMeta(secret)
+		if request.IgnoreLiteralCase {
+			pattern = caseInsensitiveFlag + pattern
+		}
+		patterns = append(patterns, CustomPattern{Name: literalSource, Pattern: pattern, Confidence: 1})
 	}
 
-	return result
+	redactions := re.collectCustomRedactions(src, patterns, request.MinConfidence)
+	for i := range redactions {
+		redactions[i].Source = literalSource
+	}
+	return redactions
+}
+
+// targetSpan returns the span of the given capture group within a match, or
+// the whole match for group 0. ok is false if the group did not participate.
+func targetSpan(match []int, groupIndex int) (start, end int, ok bool) {
+	if 2*groupIndex+1 >= len(match) {
+		return 0, 0, false
+	}
+	start, end = match[2*groupIndex], match[2*groupIndex+1]
+	return start, end, start >= 0 && end > start
+}
+
+// tokenTTL returns the lifetime of a token covering the given redactions:
+// the shortest TTL of their types, where each type uses Request.TypeTTL if
+// set and otherwise the request TTL or the engine default
+func (re *Engine) tokenTTL(request *Request, redactions ...Redaction) time.Duration {
+	base := request.TTL
+	if base == 0 {
+		base = re.defaultTTL
+	}
+
+	ttl := time.Duration(0)
+	for _, redaction := range redactions {
+		typeTTL, exists := request.TypeTTL[redaction.Type]
+		if !exists || typeTTL <= 0 {
+			typeTTL = base
+		}
+		if ttl == 0 || typeTTL < ttl {
+			ttl = typeTTL
+		}
+	}
+	if ttl == 0 {
+		return base
+	}
+	return ttl
 }
 
 // generateTokenWithTTL generates a token with custom TTL
 func (re *Engine) generateTokenWithTTL(result *Result, ttl time.Duration) string {
 	// Generate random token
-	bytes := make([]byte, 16)
-	_, _ = rand.Read(bytes)
-	token := hex.EncodeToString(bytes)
+	token := re.signToken(randomHex(16))
 
 	// Store token information with custom TTL
 	tokenInfo := TokenInfo{
@@ -833,3 +2513,10 @@ func (re *Engine) generateTokenWithTTL(result *Result, ttl time.Duration) string
 
 	return token
 }
+
+// randomHex returns n cryptographically random bytes, hex encoded
+func randomHex(n int) string {
+	bytes := make([]byte, n)
+	_, _ = rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
@@ -0,0 +1,137 @@
+package redaction
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"sync"
+)
+
+// Defaults for EntropyDetector
+const (
+	defaultEntropyThreshold = 4.0 // bits per character
+	defaultEntropyMinLength = 20
+)
+
+// entropyTokenPattern splits text into candidate tokens on whitespace and
+// punctuation, keeping the characters common in keys and base64
+var entropyTokenPattern = regexp.MustCompile(`[A-Za-z0-9+/=_\-]+`)
+
+// defaultEntropyAllowlist covers high-entropy values that are not secrets:
+// UUIDs and base64 data URIs such as inline images
+var defaultEntropyAllowlist = []*regexp.Regexp{
+	regexp.MustCompile(`\b[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\b`),
+	regexp.MustCompile(`data:[\w.+-]+/[\w.+-]+(?:;[\w.+-]+=[\w.+-]+)*;base64,[A-Za-z0-9+/=]+`),
+}
+
+// EntropyDetector is an opt-in Detector that flags tokens with high Shannon
+// entropy, such as API keys and tokens no pattern recognises, as
+// TypeHighEntropy. A token is flagged when it is at least the minimum length
+// and its entropy reaches the threshold, unless it lies within a match of an
+// allowlist pattern.
+type EntropyDetector struct {
+	threshold float64
+	minLength int
+
+	mutex     sync.RWMutex
+	allowlist []*regexp.Regexp
+}
+
+// NewEntropyDetector creates an entropy detector. A threshold or minLength
+// of zero selects the default of 4.0 bits per character or 20 characters.
+func NewEntropyDetector(threshold float64, minLength int) *EntropyDetector {
+	if threshold <= 0 {
+		threshold = defaultEntropyThreshold
+	}
+	if minLength <= 0 {
+		minLength = defaultEntropyMinLength
+	}
+
+	return &EntropyDetector{
+		threshold: threshold,
+		minLength: minLength,
+		allowlist: append([]*regexp.Regexp(nil), defaultEntropyAllowlist...),
+	}
+}
+
+// Allow adds a pattern whose matches are never flagged
+func (d *EntropyDetector) Allow(pattern string) error {
+	compiled, err := compileUserPattern(pattern)
+	if err != nil {
+		return err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.allowlist = append(d.allowlist, compiled)
+	return nil
+}
+
+// Name implements Detector
+func (d *EntropyDetector) Name() string {
+	return "entropy"
+}
+
+// Types implements TypedDetector
+func (d *EntropyDetector) Types() []Type {
+	return []Type{TypeHighEntropy}
+}
+
+// Detect implements Detector by flagging the high-entropy tokens of text
+func (d *EntropyDetector) Detect(_ context.Context, text string) ([]Redaction, error) {
+	d.mutex.RLock()
+	allowlist := d.allowlist
+	d.mutex.RUnlock()
+
+	var allowed [][]int
+	for _, pattern := range allowlist {
+		allowed = append(allowed, pattern.FindAllStringIndex(text, -1)...)
+	}
+
+	var redactions []Redaction
+	for _, match := range entropyTokenPattern.FindAllStringIndex(text, -1) {
+		start, end := match[0], match[1]
+		if end-start < d.minLength || withinAny(start, end, allowed) {
+			continue
+		}
+		if shannonEntropy(text[start:end]) < d.threshold {
+			continue
+		}
+		redactions = append(redactions, Redaction{Type: TypeHighEntropy, Start: start, End: end})
+	}
+	return redactions, nil
+}
+
+// withinAny reports whether [start, end) lies inside any of spans
+func withinAny(start, end int, spans [][]int) bool {
+	for _, span := range spans {
+		if start >= span[0] && end <= span[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// shannonEntropy returns the Shannon entropy of s in bits per byte
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	var counts [256]int
+	for i := 0; i < len(s); i++ {
+		counts[s[i]]++
+	}
+
+	entropy := 0.0
+	length := float64(len(s))
+	for _, count := range counts {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / length
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
@@ -0,0 +1,66 @@
+package redaction
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestEntropyDetector(t *testing.T) {
+	token := "q8ZrT2mVx7LpB4nWc9YsK1dHf6GjA3uE5oRiN0tX"
+
+	redact := func(t *testing.T, detector *EntropyDetector, text string) *Result {
+		t.Helper()
+		engine := NewEngine()
+		engine.AddDetector(detector)
+		result, err := engine.RedactText(context.Background(), &Request{Text: text, Mode: ModeReplace})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		return result
+	}
+
+	t.Run("Random token is flagged", func(t *testing.T) {
+		result := redact(t, NewEntropyDetector(0, 0), "Use key "+token+" for the staging cluster")
+		if result.RedactedText != "Use key [HIGH_ENTROPY_REDACTED] for the staging cluster" {
+			t.Errorf("Unexpected redacted text: %s", result.RedactedText)
+		}
+	})
+
+	t.Run("English words are not flagged", func(t *testing.T) {
+		text := "Internationalization and telecommunications infrastructure responsibilities"
+		result := redact(t, NewEntropyDetector(0, 0), text)
+		if len(result.Redactions) != 0 {
+			t.Errorf("Expected no redactions, got %v", getRedactionTypes(result.Redactions))
+		}
+	})
+
+	t.Run("UUIDs and base64 images are allowed", func(t *testing.T) {
+		detector := NewEntropyDetector(3.0, 10)
+		text := "id 7f3c2a9e-4b1d-4e8a-9c6f-2d5b8a1e0c3f img data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNk"
+		found, err := detector.Detect(context.Background(), text)
+		if err != nil {
+			t.Fatalf("Detect failed: %v", err)
+		}
+		if len(found) != 0 {
+			t.Errorf("Expected allowlisted values to be kept, got %+v", found)
+		}
+	})
+
+	t.Run("Threshold and allowlist are tunable", func(t *testing.T) {
+		if result := redact(t, NewEntropyDetector(6, 0), token); len(result.Redactions) != 0 {
+			t.Errorf("Expected no redactions above the token's entropy, got %v", getRedactionTypes(result.Redactions))
+		}
+
+		detector := NewEntropyDetector(0, 0)
+		if err := detector.Allow(`q8Zr\w+`); err != nil {
+			t.Fatalf("Allow failed: %v", err)
+		}
+		if err := detector.Allow(`(`); err == nil {
+			t.Error("Expected an invalid allowlist pattern to fail")
+		}
+		if result := redact(t, detector, token); strings.Contains(result.RedactedText, "REDACTED") {
+			t.Errorf("Expected the allowlisted token to be kept, got %s", result.RedactedText)
+		}
+	})
+}
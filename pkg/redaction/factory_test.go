@@ -2,6 +2,8 @@ package redaction
 
 import (
 	"context"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 )
@@ -140,6 +142,42 @@ func TestPolicyValidation(t *testing.T) {
 	}
 }
 
+func TestFactoryProviderInterfaces(t *testing.T) {
+	factory := NewProviderFactory()
+	ctx := context.Background()
+
+	basic, err := factory.CreateProvider(ProviderTypeBasic, nil)
+	if err != nil {
+		t.Fatalf("Failed to create basic provider: %v", err)
+	}
+	if _, ok := basic.(EngineInterface); !ok {
+		t.Errorf("Basic provider should implement EngineInterface, got %T", basic)
+	}
+
+	provider, err := factory.CreateProvider(ProviderTypePolicyAware, nil)
+	if err != nil {
+		t.Fatalf("Failed to create policy-aware provider: %v", err)
+	}
+	policyAware, ok := provider.(PolicyAwareEngine)
+	if !ok {
+		t.Fatalf("Policy-aware provider should implement PolicyAwareEngine, got %T", provider)
+	}
+
+	// The rule's pattern is not a built-in type, so only the rule can match it
+	result, err := policyAware.ApplyPolicyRules(ctx, &PolicyRequest{
+		Request: &Request{Text: "Ticket PROJ-4821 is open", Mode: ModeReplace},
+		PolicyRules: []PolicyRule{
+			{Name: "PROJECT_ID", Patterns: []string{`PROJ-\d{4}`}, Mode: ModeReplace, Enabled: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ApplyPolicyRules failed: %v", err)
+	}
+	if len(result.Redactions) != 1 || strings.Contains(result.RedactedText, "PROJ-4821") {
+		t.Errorf("Expected the policy rule to redact the project ID, got %q %+v", result.RedactedText, result.Redactions)
+	}
+}
+
 func TestProviderTypeSupport(t *testing.T) {
 	factory := NewProviderFactory()
 	supportedTypes := factory.GetSupportedProviderTypes()
@@ -157,3 +195,80 @@ func TestProviderTypeSupport(t *testing.T) {
 		t.Error("ProviderTypePolicyAware should be in supported provider types")
 	}
 }
+
+func TestPolicyRuleStrategies(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+
+	text := "Contact jane@example.com, SSN 123-45-6789"
+	policyRequest := &PolicyRequest{
+		Request: &Request{Text: text, Mode: ModeReplace},
+		PolicyRules: []PolicyRule{
+			{
+				Name:     "EMAIL_HASH",
+				Patterns: []string{`[a-z]+@example\.com`},
+				Mode:     ModeReplace,
+				Strategy: "consistent_hash",
+				Priority: 10,
+				Enabled:  true,
+			},
+			{
+				Name:     "SSN_FORMAT",
+				Patterns: []string{`\d{3}-\d{2}-\d{4}`},
+				Mode:     ModeReplace,
+				Strategy: "format_preserving",
+				Priority: 10,
+				Enabled:  true,
+			},
+		},
+	}
+
+	if errs := engine.ValidatePolicy(ctx, policyRequest.PolicyRules); len(errs) != 0 {
+		t.Fatalf("Expected no validation errors, got %v", errs)
+	}
+
+	result, err := engine.ApplyPolicyRules(ctx, policyRequest)
+	if err != nil {
+		t.Fatalf("ApplyPolicyRules failed: %v", err)
+	}
+
+	if len(result.Redactions) != 2 {
+		t.Fatalf("Expected 2 redactions, got %d: %+v", len(result.Redactions), result.Redactions)
+	}
+
+	byType := make(map[Type]Redaction)
+	for _, redaction := range result.Redactions {
+		byType[redaction.Type] = redaction
+	}
+
+	email, ok := byType[TypeEmail]
+	if !ok || !regexp.MustCompile(`^user_[0-9a-f]+@redacted\.com$`).MatchString(email.Replacement) {
+		t.Errorf("Expected consistent hash email replacement, got %+v", email)
+	}
+
+	ssn, ok := byType[TypeSSN]
+	if !ok || !regexp.MustCompile(`^\d{3}-\d{2}-\d{4}$`).MatchString(ssn.Replacement) || ssn.Replacement == ssn.Original {
+		t.Errorf("Expected format preserving SSN replacement, got %+v", ssn)
+	}
+
+	if strings.Contains(result.RedactedText, "jane@example.com") || strings.Contains(result.RedactedText, "123-45-6789") {
+		t.Errorf("Original values leaked into redacted text: %s", result.RedactedText)
+	}
+
+	// Without a strategy the rule falls back to its mode
+	policyRequest.PolicyRules[0].Strategy = ""
+	result, err = engine.ApplyPolicyRules(ctx, policyRequest)
+	if err != nil {
+		t.Fatalf("ApplyPolicyRules failed: %v", err)
+	}
+	if !strings.Contains(result.RedactedText, "[EMAIL_REDACTED]") {
+		t.Errorf("Expected mode-based email replacement, got %s", result.RedactedText)
+	}
+
+	// Unknown strategies are reported by validation
+	policyRequest.PolicyRules[1].Strategy = "no_such_strategy"
+	errs := engine.ValidatePolicy(ctx, policyRequest.PolicyRules)
+	if len(errs) != 1 || errs[0].Code != "INVALID_STRATEGY" {
+		t.Errorf("Expected one INVALID_STRATEGY error, got %v", errs)
+	}
+}
@@ -0,0 +1,156 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrFieldMismatch is returned by RedactField in strict mode when the value
+// does not wholly match the expected type
+var ErrFieldMismatch = errors.New("field value does not match the expected type")
+
+// SetStrictFields controls how RedactField treats values that do not match
+// their expected type: left unchanged (the default) or rejected with
+// ErrFieldMismatch
+func (re *Engine) SetStrictFields(strict bool) {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.strictFields = strict
+}
+
+// fieldValuePatterns match the bare values of types whose patterns only
+// match after a keyword, such as "account" before a bank account number. A
+// field known to hold the type already vouches for its values, so the
+// keyword is not required there.
+var fieldValuePatterns = map[Type]*regexp.Regexp{
+	TypeZipCode:     regexp.MustCompile(`^\d{5}(?:-\d{4})?$`),
+	TypeBankAccount: regexp.MustCompile(`^\d{6,17}$`),
+	TypeUSPassport:  regexp.MustCompile(`^(?:[A-Za-z]\d{8}|\d{9})$`),
+}
+
+// RedactField redacts a single field value known to hold one value of
+// expectedType, such as a column of SSNs. The type's pattern must match the
+// whole value; embedded matches are ignored and no other type is scanned for.
+// Types whose patterns need a keyword, such as bank accounts, match their
+// bare values. Values that do not match are returned unchanged, or rejected
+// with ErrFieldMismatch when strict fields are enabled.
+func (re *Engine) RedactField(ctx context.Context, value string, expectedType Type, mode Mode) (*Result, error) {
+	return re.redactField(ctx, value, expectedType, &Request{Mode: mode}, false)
+}
+
+// redactField implements RedactField, redacting with the mode, strategy and
+// other replacement settings of template. When reformatted is set, a number
+// the pattern rejects still matches if its digits pass the type's identifier
+// check, so "123.45.6789" in a field known to hold SSNs is redacted.
+func (re *Engine) redactField(ctx context.Context, value string, expectedType Type, template *Request, reformatted bool) (*Result, error) {
+	request := &Request{}
+	if template != nil {
+		*request = *template
+	}
+	request.Text = value
+	request.Types = []Type{expectedType}
+	if err := re.checkRequest(ctx, request, len(request.Text)); err != nil {
+		return nil, err
+	}
+
+	pattern, err := re.anchoredPattern(expectedType)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		OriginalText: value,
+		RedactedText: value,
+		Redactions:   []Redaction{},
+		Timestamp:    time.Now(),
+	}
+
+	matches := pattern.MatchString(value) && re.isValidMatch(expectedType, value)
+	if bare, guarded := fieldValuePatterns[expectedType]; guarded && !matches {
+		matches = bare.MatchString(value)
+	}
+	if !matches && reformatted {
+		check, exists := identifierChecks[expectedType]
+		matches = exists && isFormattedNumber(value) && check(value)
+	}
+	if !matches {
+		re.mutex.RLock()
+		strict := re.strictFields
+		re.mutex.RUnlock()
+
+		if strict {
+			return nil, fmt.Errorf("%w: %s", ErrFieldMismatch, expectedType)
+		}
+		return result, nil
+	}
+
+	redaction := Redaction{
+		Type:        expectedType,
+		Start:       0,
+		End:         len(value),
+		Original:    value,
+		Replacement: re.generateReplacement(expectedType, value),
+		Confidence:  defaultConfidence,
+	}
+	if err := re.applyReplacement(ctx, request, &redaction); err != nil {
+		return nil, err
+	}
+
+	result.Redactions = append(result.Redactions, redaction)
+	result.RedactedText = redaction.Replacement
+	re.recordMatches(result.Redactions)
+
+	return re.finishResult(result, request), nil
+}
+
+// anchoredPattern returns the type's pattern anchored to the whole input.
+// Anchored patterns are cached by source, so recompiled type patterns (see
+// SetCaseSensitive) are picked up automatically.
+func (re *Engine) anchoredPattern(redactionType Type) (*regexp.Regexp, error) {
+	re.mutex.RLock()
+	pattern, exists := re.patterns[redactionType]
+	var anchored *regexp.Regexp
+	if exists {
+		anchored = re.anchoredPatterns[pattern.String()]
+	}
+	re.mutex.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("no pattern registered for type: %s", redactionType)
+	}
+	if anchored != nil {
+		return anchored, nil
+	}
+
+	anchored, err := regexp.Compile(`^(?:` + pattern.String() + `)$`)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex pattern: %v", err)
+	}
+
+	re.mutex.Lock()
+	re.anchoredPatterns[pattern.String()] = anchored
+	re.mutex.Unlock()
+
+	return anchored, nil
+}
+
+// isFormattedNumber reports whether value is digits with optional spaces,
+// dashes, dots and slashes between them
+func isFormattedNumber(value string) bool {
+	digits := 0
+	for _, char := range strings.TrimSpace(value) {
+		switch {
+		case char >= '0' && char <= '9':
+			digits++
+		case char == ' ', char == '-', char == '.', char == '/':
+		default:
+			return false
+		}
+	}
+	return digits > 0
+}
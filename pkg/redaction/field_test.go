@@ -0,0 +1,81 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRedactField(t *testing.T) {
+	ctx := context.Background()
+	engine := NewEngine()
+
+	result, err := engine.RedactField(ctx, "123-45-6789", TypeSSN, ModeReplace)
+	if err != nil {
+		t.Fatalf("RedactField failed: %v", err)
+	}
+	if result.RedactedText != "[SSN_REDACTED]" || len(result.Redactions) != 1 {
+		t.Errorf("Expected the whole field redacted, got %+v", result)
+	}
+
+	// An embedded SSN is not a full match and is left alone
+	result, err = engine.RedactField(ctx, "SSN 123-45-6789", TypeSSN, ModeReplace)
+	if err != nil {
+		t.Fatalf("RedactField failed: %v", err)
+	}
+	if result.RedactedText != "SSN 123-45-6789" || len(result.Redactions) != 0 {
+		t.Errorf("Expected a non-matching field to be unchanged, got %+v", result)
+	}
+
+	engine.SetStrictFields(true)
+	if _, err := engine.RedactField(ctx, "SSN 123-45-6789", TypeSSN, ModeReplace); !errors.Is(err, ErrFieldMismatch) {
+		t.Errorf("Expected ErrFieldMismatch in strict mode, got %v", err)
+	}
+
+	if _, err := engine.RedactField(ctx, "x", Type("unknown"), ModeReplace); err == nil {
+		t.Error("Expected an error for a type without a pattern")
+	}
+}
+
+func TestRedactFieldKeywordGuardedTypes(t *testing.T) {
+	ctx := context.Background()
+	engine := NewEngine()
+
+	testCases := []struct {
+		redactionType Type
+		value         string
+	}{
+		{TypeBankAccount, "12345678901"},
+		{TypeUSPassport, "123456789"},
+		{TypeZipCode, "90210"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.redactionType), func(t *testing.T) {
+			result, err := engine.RedactField(ctx, tc.value, tc.redactionType, ModeReplace)
+			if err != nil {
+				t.Fatalf("RedactField failed: %v", err)
+			}
+			if result.RedactedText != DefaultReplacement(tc.redactionType) {
+				t.Errorf("Expected the bare value redacted, got %q", result.RedactedText)
+			}
+		})
+	}
+}
+
+func TestRedactFieldTemplate(t *testing.T) {
+	template := &Request{
+		Mode: ModeReplace,
+		ReplacerFunc: func(redaction Redaction) string {
+			return "<" + string(redaction.Type) + ">"
+		},
+	}
+
+	result, err := NewEngine().redactField(context.Background(), "123-45-6789", TypeSSN, template, false)
+	if err != nil {
+		t.Fatalf("redactField failed: %v", err)
+	}
+	if result.RedactedText != "<ssn>" {
+		t.Errorf("Expected the template's replacer to be used, got %q", result.RedactedText)
+	}
+}
@@ -0,0 +1,87 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"unicode/utf8"
+)
+
+// fuzzModes are the modes FuzzRedactText picks from
+var fuzzModes = []Mode{ModeReplace, ModeMask, ModeRemove, ModeTokenize, ModeHash, ModeEncrypt, ModeSignature, ModeLabelHash}
+
+// FuzzRedactText redacts random text with a random custom pattern, literal
+// secret and option set, checking that every redaction's offsets lie inside
+// the text, match its Original and rune offsets, and do not overlap their
+// neighbours
+func FuzzRedactText(f *testing.F) {
+	f.Add("Email john@example.com, NI AB123456C", `\d+`, uint8(0), uint8(0))
+	f.Add("card 4111 1111 1111 1111 and 4111-1111-1111-1111", `1111`, uint8(1), uint8(0x0f))
+	f.Add("jo​hn@exam­ple.com", `.`, uint8(2), uint8(0x01))
+	f.Add("é😀 07700 900123 😀é", `\S*`, uint8(3), uint8(0x06))
+	f.Add("", `x*`, uint8(4), uint8(0x1f))
+	f.Add("key=abc key=abcd", `key=(?P<g>\w+)`, uint8(5), uint8(0x70))
+
+	engine := NewEngine()
+	engine.SetHashSalt("fuzz-salt")
+	if err := engine.SetEncryptionKey([]byte("0123456789abcdef")); err != nil {
+		f.Fatalf("SetEncryptionKey failed: %v", err)
+	}
+	if err := engine.SetSignatureKey([]byte("fuzz-key")); err != nil {
+		f.Fatalf("SetSignatureKey failed: %v", err)
+	}
+	f.Fuzz(func(t *testing.T, text, pattern string, mode, flags uint8) {
+		options := map[string]interface{}{
+			"normalize_unicode":   flags&0x01 != 0,
+			"collapse_whitespace": flags&0x02 != 0,
+			"preserve_length":     flags&0x04 != 0,
+			"validated_only":      flags&0x08 != 0,
+			"context_capture":     "redacted",
+		}
+		if flags&0x10 != 0 {
+			options["merge_adjacent"] = 1
+		}
+		if flags&0x20 != 0 {
+			options["overlap_policy"] = "annotate"
+		}
+
+		custom := CustomPattern{Name: "fuzz", Pattern: pattern}
+		if flags&0x40 != 0 {
+			custom.Group = "g"
+		}
+
+		result, err := engine.RedactText(context.Background(), &Request{
+			Text:           text,
+			Mode:           fuzzModes[int(mode)%len(fuzzModes)],
+			CustomPatterns: []CustomPattern{custom},
+			LiteralSecrets: []string{pattern},
+			Options:        options,
+		})
+		if errors.Is(err, ErrInvalidPattern) {
+			return
+		}
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+
+		last := 0
+		for _, redaction := range result.Redactions {
+			if redaction.Start < last || redaction.End < redaction.Start || redaction.End > len(text) {
+				t.Fatalf("Redaction [%d, %d) out of order or bounds in %d bytes: %+v", redaction.Start, redaction.End, len(text), result.Redactions)
+			}
+			if text[redaction.Start:redaction.End] != redaction.Original {
+				t.Fatalf("Original %q does not match text[%d:%d] = %q", redaction.Original, redaction.Start, redaction.End, text[redaction.Start:redaction.End])
+			}
+			if redaction.RuneStart != countRunes(text[:redaction.Start]) || redaction.RuneEnd != countRunes(text[:redaction.End]) {
+				t.Fatalf("Rune offsets [%d, %d) do not match byte offsets [%d, %d)", redaction.RuneStart, redaction.RuneEnd, redaction.Start, redaction.End)
+			}
+			last = redaction.End
+		}
+		if flags&0x02 == 0 && result.RedactedText != applyRedactionsToText(text, result.Redactions) {
+			t.Fatalf("RedactedText %q does not match the redactions applied to the text", result.RedactedText)
+		}
+		if utf8.ValidString(text) && !utf8.ValidString(result.RedactedText) && !optionBool(options, "preserve_length") {
+			t.Fatalf("Redacting valid UTF-8 produced invalid UTF-8: %q", result.RedactedText)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package redaction
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxGrowthRatioOption rejects requests whose redacted text would be more
+// than this many times the length of the original, as encrypt and signature
+// replacements can be, with ErrOutputTooLarge
+const maxGrowthRatioOption = "max_growth_ratio"
+
+// ErrOutputTooLarge is returned when the redacted text grows past the
+// request's max_growth_ratio
+var ErrOutputTooLarge = errors.New("redacted output too large")
+
+// setGrowth records the lengths of a result's original and redacted text and
+// their ratio
+func setGrowth(result *Result, original, redacted int) {
+	result.OriginalLength = original
+	result.RedactedLength = redacted
+	result.GrowthRatio = 0
+	if result.OriginalLength > 0 {
+		result.GrowthRatio = float64(result.RedactedLength) / float64(result.OriginalLength)
+	}
+}
+
+// checkGrowth fails when redacting original bytes into redacted bytes grew
+// the output past the request's max_growth_ratio. Limits of zero or less,
+// and empty originals, are not checked.
+func checkGrowth(request *Request, original, redacted int) error {
+	limit := optionFloat(request.Options, maxGrowthRatioOption)
+	if limit <= 0 || original == 0 {
+		return nil
+	}
+	if ratio := float64(redacted) / float64(original); ratio > limit {
+		return fmt.Errorf("%w: %d bytes redacted from %d, a growth ratio of %.2f exceeding %.2f",
+			ErrOutputTooLarge, redacted, original, ratio, limit)
+	}
+	return nil
+}
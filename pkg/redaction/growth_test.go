@@ -0,0 +1,58 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestOutputGrowth(t *testing.T) {
+	engine := NewEngine()
+	if err := engine.SetEncryptionKey([]byte("0123456789abcdef")); err != nil {
+		t.Fatalf("SetEncryptionKey failed: %v", err)
+	}
+	ctx := context.Background()
+	text := "SSN 123-45-6789"
+
+	t.Run("lengths are reported", func(t *testing.T) {
+		result, err := engine.RedactText(ctx, &Request{Text: text, Mode: ModeReplace})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		if result.OriginalLength != len(text) || result.RedactedLength != len(result.RedactedText) {
+			t.Errorf("Expected lengths %d and %d, got %d and %d",
+				len(text), len(result.RedactedText), result.OriginalLength, result.RedactedLength)
+		}
+		if want := float64(len(result.RedactedText)) / float64(len(text)); result.GrowthRatio != want {
+			t.Errorf("Expected growth ratio %v, got %v", want, result.GrowthRatio)
+		}
+	})
+
+	t.Run("encrypt mode exceeding the ratio fails", func(t *testing.T) {
+		result, err := engine.RedactText(ctx, &Request{
+			Text:    text,
+			Mode:    ModeEncrypt,
+			Options: map[string]interface{}{"max_growth_ratio": 2.0},
+		})
+		if !errors.Is(err, ErrOutputTooLarge) {
+			t.Fatalf("Expected ErrOutputTooLarge, got %v", err)
+		}
+		if result != nil {
+			t.Errorf("Expected no result, got %+v", result)
+		}
+	})
+
+	t.Run("growth within the ratio succeeds", func(t *testing.T) {
+		result, err := engine.RedactText(ctx, &Request{
+			Text:    text,
+			Mode:    ModeEncrypt,
+			Options: map[string]interface{}{"max_growth_ratio": 20},
+		})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		if result.GrowthRatio <= 2 || result.GrowthRatio > 20 {
+			t.Errorf("Expected a growth ratio between 2 and 20, got %v", result.GrowthRatio)
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package redaction
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// RedactGzip redacts gzip-compressed text read from r, as RedactStream does,
+// and writes the redacted text to w gzip-compressed, keeping the input's
+// header. Corrupt or truncated input is reported as an error; the output
+// written before it is not a complete gzip stream.
+func (re *Engine) RedactGzip(ctx context.Context, r io.Reader, w io.Writer, opts *StreamOptions) (*StreamResult, error) {
+	reader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gzip input: %w", err)
+	}
+	defer reader.Close()
+
+	writer := gzip.NewWriter(w)
+	writer.Header = reader.Header
+
+	result, err := re.RedactStream(ctx, gzipInput{reader}, writer, opts)
+	if err != nil {
+		return result, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return result, fmt.Errorf("error writing gzip output: %w", err)
+	}
+	return result, nil
+}
+
+// gzipInput labels decompression errors so they are not mistaken for errors
+// of the underlying reader
+type gzipInput struct {
+	reader *gzip.Reader
+}
+
+func (g gzipInput) Read(p []byte) (int, error) {
+	n, err := g.reader.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) {
+		err = fmt.Errorf("corrupt or truncated gzip input: %w", err)
+	}
+	return n, err
+}
@@ -0,0 +1,73 @@
+package redaction
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func gzipText(t *testing.T, text string) []byte {
+	t.Helper()
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	writer.Name = "app.log"
+	if _, err := writer.Write([]byte(text)); err != nil {
+		t.Fatalf("gzip write failed: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("gzip close failed: %v", err)
+	}
+	return compressed.Bytes()
+}
+
+func TestRedactGzip(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+	text := strings.Repeat("INFO login ok\n", 50) + "WARN reset sent to jane@example.com\n"
+
+	var output bytes.Buffer
+	result, err := engine.RedactGzip(ctx, bytes.NewReader(gzipText(t, text)), &output, nil)
+	if err != nil {
+		t.Fatalf("RedactGzip failed: %v", err)
+	}
+	if len(result.Redactions) != 1 || result.Redactions[0].Type != TypeEmail {
+		t.Errorf("Expected the email redacted, got %+v", result.Redactions)
+	}
+
+	reader, err := gzip.NewReader(&output)
+	if err != nil {
+		t.Fatalf("Output is not gzip: %v", err)
+	}
+	redacted, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Output does not decompress: %v", err)
+	}
+	expected := strings.Replace(text, "jane@example.com", "[EMAIL_REDACTED]", 1)
+	if string(redacted) != expected {
+		t.Errorf("Unexpected decompressed output:\n%s", redacted)
+	}
+	if reader.Name != "app.log" {
+		t.Errorf("Expected the gzip header to be kept, got name %q", reader.Name)
+	}
+}
+
+func TestRedactGzipCorruptInput(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+	compressed := gzipText(t, strings.Repeat("contact jane@example.com\n", 100))
+
+	_, err := engine.RedactGzip(ctx, strings.NewReader("plain text, not gzip"), io.Discard, nil)
+	if !errors.Is(err, gzip.ErrHeader) {
+		t.Errorf("Expected a gzip header error, got %v", err)
+	}
+
+	_, err = engine.RedactGzip(ctx, bytes.NewReader(compressed[:len(compressed)/2]), io.Discard, nil)
+	if !errors.Is(err, io.ErrUnexpectedEOF) || !strings.Contains(err.Error(), "truncated gzip") {
+		t.Errorf("Expected a truncated gzip error, got %v", err)
+	}
+}
@@ -10,15 +10,21 @@ type Mode string
 
 // Redaction mode constants for different redaction strategies
 const (
-	ModeReplace  Mode = "replace"  // Replace with placeholder
-	ModeMask     Mode = "mask"     // Replace with mask characters
-	ModeRemove   Mode = "remove"   // Remove entirely
-	ModeTokenize Mode = "tokenize" // Replace with reversible token
-	ModeHash     Mode = "hash"     // Replace with hash
-	ModeEncrypt  Mode = "encrypt"  // Replace with encrypted value
-	ModeLLM      Mode = "llm"      // Use LLM for context-aware redaction
+	ModeReplace   Mode = "replace"    // Replace with placeholder
+	ModeMask      Mode = "mask"       // Replace with mask characters
+	ModeRemove    Mode = "remove"     // Remove entirely
+	ModeTokenize  Mode = "tokenize"   // Replace with reversible token
+	ModeHash      Mode = "hash"       // Replace with hash
+	ModeEncrypt   Mode = "encrypt"    // Replace with encrypted value
+	ModeSignature Mode = "signature"  // Replace with a keyed signature for record linkage
+	ModeLabelHash Mode = "label_hash" // Replace with the type label and a short keyed hash
+	ModeLLM       Mode = "llm"        // Use LLM for context-aware redaction
 )
 
+// StrategyDefault selects, as Request.Strategy, the strategy registry's
+// default strategy for each detected type
+const StrategyDefault = "default"
+
 // EngineInterface defines the interface for redaction implementations
 // This allows for pluggable redaction strategies including pattern-based and LLM-based redaction
 type EngineInterface interface {
@@ -91,6 +97,34 @@ type Request struct {
 	Options        map[string]interface{} `json:"options,omitempty"`
 	Reversible     bool                   `json:"reversible"`
 	TTL            time.Duration          `json:"ttl,omitempty"`
+	MinConfidence  float64                `json:"min_confidence,omitempty"`  // Matches below this confidence are ignored
+	Strategy       string                 `json:"strategy,omitempty"`        // Replacement strategy name, overrides Mode
+	IncludeMapping bool                   `json:"include_mapping,omitempty"` // Return Result.Mapping; it holds plaintext
+	MaxTextLength  int                    `json:"max_text_length,omitempty"` // Per-request text length cap, bounded by the engine ceiling
+
+	// TypeTTL shortens or lengthens the token lifetime for results containing
+	// the listed types; a token expires with the shortest TTL among the types
+	// it covers, types not listed using TTL
+	TypeTTL map[Type]time.Duration `json:"type_ttl,omitempty"`
+
+	// TypePriorities override the default priorities that decide which of
+	// two equal-length overlapping matches wins; higher wins. Types not
+	// listed keep their default priority.
+	TypePriorities map[Type]int `json:"type_priorities,omitempty"`
+
+	// TypeModes override Mode for the listed types, so that one request can,
+	// say, encrypt SSNs and mask emails
+	TypeModes map[Type]Mode `json:"type_modes,omitempty"`
+
+	// LiteralSecrets are exact values, such as a leaked password, redacted
+	// wherever they occur whatever their type. IgnoreLiteralCase matches
+	// them case-insensitively.
+	LiteralSecrets    []string `json:"literal_secrets,omitempty"`
+	IgnoreLiteralCase bool     `json:"ignore_literal_case,omitempty"`
+
+	// ReplacerFunc, when set, produces the replacement for each detected match
+	// and takes precedence over the mode
+	ReplacerFunc func(Redaction) string `json:"-"`
 }
 
 // PolicyRequest represents a policy-driven redaction request
@@ -136,6 +170,7 @@ type CustomPattern struct {
 	Replacement string  `json:"replacement,omitempty"`
 	Confidence  float64 `json:"confidence,omitempty"`
 	Description string  `json:"description,omitempty"`
+	Group       string  `json:"group,omitempty"` // Named capture group to redact; the rest of the match is kept
 }
 
 // PolicyRule represents a policy-defined redaction rule
@@ -144,6 +179,7 @@ type PolicyRule struct {
 	Patterns   []string               `json:"patterns"`
 	Fields     []string               `json:"fields"`
 	Mode       Mode                   `json:"mode"`
+	Strategy   string                 `json:"strategy,omitempty"` // named replacement strategy, overrides Mode
 	Conditions []PolicyCondition      `json:"conditions,omitempty"`
 	Priority   int                    `json:"priority"`
 	Enabled    bool                   `json:"enabled"`
@@ -173,6 +209,7 @@ type EngineCapabilities struct {
 	Version            string          `json:"version"`
 	SupportedTypes     []Type          `json:"supported_types"`
 	SupportedModes     []Mode          `json:"supported_modes"`
+	ImplementedModes   []Mode          `json:"implemented_modes"` // Supported modes with their own replacement; others fall back to replace
 	SupportsReversible bool            `json:"supports_reversible"`
 	SupportsCustom     bool            `json:"supports_custom_patterns"`
 	SupportsLLM        bool            `json:"supports_llm"`
@@ -198,6 +235,7 @@ type Pattern struct {
 	Name        string                 `json:"name" yaml:"name"`
 	Category    string                 `json:"category" yaml:"category"`
 	Regex       string                 `json:"regex" yaml:"regex"`
+	Group       string                 `json:"group,omitempty" yaml:"group,omitempty"` // Named capture group to redact, see Pattern.CustomPattern
 	Replacement string                 `json:"replacement" yaml:"replacement"`
 	Confidence  float64                `json:"confidence" yaml:"confidence"`
 	Description string                 `json:"description" yaml:"description"`
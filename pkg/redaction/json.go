@@ -0,0 +1,160 @@
+package redaction
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// pathMetadataKey is the Redaction.Metadata key holding the JSON path of the
+// value a RedactJSON redaction was found in
+const pathMetadataKey = "path"
+
+// JSONOptions configures RedactJSON
+type JSONOptions struct {
+	// Request is the template for free-text strings and schema-listed
+	// values alike
+	Request *Request
+
+	// Schema maps a path, the dot-separated object keys leading to a value
+	// such as "customer.ssn", to the one type that value holds. Array
+	// elements share the path of their array. Listed values are redacted
+	// whole with RedactField semantics under the Request's settings, so a bare
+	// bank account or ZIP code needs no keyword, and numbers formatted in
+	// unusual ways that pass the type's identifier check are accepted; other
+	// string values are scanned as free text.
+	Schema map[string]Type
+}
+
+// JSONResult summarises a JSON redaction
+type JSONResult struct {
+	Redactions []Redaction `json:"redactions"` // Offsets are relative to the value; Metadata["path"] names it
+	Errors     []string    `json:"errors,omitempty"`
+}
+
+// RedactJSON redacts the string values of a JSON document and returns the
+// re-encoded document. Object keys are kept, and numbers and booleans pass
+// through unless the schema lists their path, in which case a redacted
+// number becomes a string.
+func (re *Engine) RedactJSON(ctx context.Context, data []byte, opts JSONOptions) ([]byte, *JSONResult, error) {
+	request := segmentRequest(opts.Request)
+	if err := re.checkRequest(ctx, request, 0); err != nil {
+		return nil, nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, nil, fmt.Errorf("invalid JSON: unexpected data after the document")
+	}
+
+	walker := &jsonWalker{engine: re, request: request, schema: opts.Schema, segments: &Result{}}
+	redacted, err := walker.walk(ctx, document, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var output bytes.Buffer
+	encoder := json.NewEncoder(&output)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(redacted); err != nil {
+		return nil, nil, fmt.Errorf("error encoding JSON: %w", err)
+	}
+
+	result := &JSONResult{Redactions: walker.segments.Redactions, Errors: walker.segments.Errors}
+	if result.Redactions == nil {
+		result.Redactions = []Redaction{}
+	}
+	return bytes.TrimSuffix(output.Bytes(), []byte("\n")), result, nil
+}
+
+// jsonWalker redacts the values of a decoded JSON document
+type jsonWalker struct {
+	engine   *Engine
+	request  *Request
+	schema   map[string]Type
+	segments *Result
+}
+
+// walk returns value with its strings redacted. path holds the object keys
+// leading to value.
+func (w *jsonWalker) walk(ctx context.Context, value interface{}, path []string) (interface{}, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, child := range typed {
+			redacted, err := w.walk(ctx, child, append(path, key))
+			if err != nil {
+				return nil, err
+			}
+			typed[key] = redacted
+		}
+		return typed, nil
+	case []interface{}:
+		for i, child := range typed {
+			redacted, err := w.walk(ctx, child, path)
+			if err != nil {
+				return nil, err
+			}
+			typed[i] = redacted
+		}
+		return typed, nil
+	case string:
+		return w.redactValue(ctx, typed, path, false)
+	case json.Number:
+		return w.redactValue(ctx, typed.String(), path, true)
+	default:
+		return value, nil
+	}
+}
+
+// redactValue redacts a string or number found at path. Numbers are only
+// redacted when the schema lists their path; otherwise they are returned as
+// the json.Number they were decoded from.
+func (w *jsonWalker) redactValue(ctx context.Context, value string, path []string, number bool) (interface{}, error) {
+	joined := strings.Join(path, ".")
+	start := len(w.segments.Redactions)
+
+	redactionType, typed := w.schema[joined]
+	var redacted string
+	switch {
+	case typed:
+		field, err := w.engine.redactField(ctx, value, redactionType, w.request, true)
+		if err != nil {
+			return nil, fmt.Errorf("path %s: %w", joined, err)
+		}
+		if len(field.Redactions) == 0 && number {
+			return json.Number(value), nil
+		}
+		w.segments.Redactions = append(w.segments.Redactions, field.Redactions...)
+		redacted = field.RedactedText
+	case number:
+		return json.Number(value), nil
+	default:
+		var err error
+		if redacted, err = w.engine.redactSegment(ctx, w.request, value, nil, w.segments); err != nil {
+			return nil, fmt.Errorf("path %s: %w", joined, err)
+		}
+	}
+
+	for i := start; i < len(w.segments.Redactions); i++ {
+		if w.segments.Redactions[i].Metadata == nil {
+			w.segments.Redactions[i].Metadata = make(map[string]interface{})
+		}
+		w.segments.Redactions[i].Metadata[pathMetadataKey] = joined
+	}
+	return redacted, nil
+}
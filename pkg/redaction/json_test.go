@@ -0,0 +1,113 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRedactJSON(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+
+	input := `{
+		"customer": {"name": "Jane", "ssn": "123.45.6789", "notes": "Reach me at jane@example.com & soon"},
+		"orders": [{"id": 1042, "ssn": 234567890}],
+		"active": true
+	}`
+
+	output, result, err := engine.RedactJSON(ctx, []byte(input), JSONOptions{
+		Schema: map[string]Type{"customer.ssn": TypeSSN},
+	})
+	if err != nil {
+		t.Fatalf("RedactJSON failed: %v", err)
+	}
+
+	expected := `{"active":true,"customer":{"name":"Jane","notes":"Reach me at [EMAIL_REDACTED] & soon","ssn":"[SSN_REDACTED]"},"orders":[{"id":1042,"ssn":234567890}]}`
+	if string(output) != expected {
+		t.Errorf("Unexpected JSON output:\n%s", output)
+	}
+
+	paths := make(map[string]Type)
+	for _, redaction := range result.Redactions {
+		paths[redaction.Metadata[pathMetadataKey].(string)] = redaction.Type
+	}
+	if len(paths) != 2 || paths["customer.ssn"] != TypeSSN || paths["customer.notes"] != TypeEmail {
+		t.Errorf("Expected the SSN and email attributed to their paths, got %v", paths)
+	}
+
+	t.Run("Schema numbers in arrays", func(t *testing.T) {
+		output, _, err := engine.RedactJSON(ctx, []byte(input), JSONOptions{
+			Schema: map[string]Type{"orders.ssn": TypeSSN},
+		})
+		if err != nil {
+			t.Fatalf("RedactJSON failed: %v", err)
+		}
+		expected := `{"active":true,"customer":{"name":"Jane","notes":"Reach me at [EMAIL_REDACTED] & soon","ssn":"123.45.6789"},"orders":[{"id":1042,"ssn":"[SSN_REDACTED]"}]}`
+		if string(output) != expected {
+			t.Errorf("Unexpected JSON output:\n%s", output)
+		}
+	})
+
+	t.Run("Schema values must still validate", func(t *testing.T) {
+		output, _, err := engine.RedactJSON(ctx, []byte(`{"customer":{"ssn":"000.00.0000"}}`), JSONOptions{
+			Schema: map[string]Type{"customer.ssn": TypeSSN},
+		})
+		if err != nil {
+			t.Fatalf("RedactJSON failed: %v", err)
+		}
+		if string(output) != `{"customer":{"ssn":"000.00.0000"}}` {
+			t.Errorf("Expected an invalid SSN to be kept, got %s", output)
+		}
+	})
+
+	t.Run("Schema hints for keyword-guarded types", func(t *testing.T) {
+		input := `{"billing":{"account":"12345678901","zip":90210},"passport":123456789}`
+		output, result, err := engine.RedactJSON(ctx, []byte(input), JSONOptions{
+			Schema: map[string]Type{
+				"billing.account": TypeBankAccount,
+				"billing.zip":     TypeZipCode,
+				"passport":        TypeUSPassport,
+			},
+		})
+		if err != nil {
+			t.Fatalf("RedactJSON failed: %v", err)
+		}
+		expected := `{"billing":{"account":"[BANK_ACCOUNT_REDACTED]","zip":"[ZIP_CODE_REDACTED]"},"passport":"[US_PASSPORT_REDACTED]"}`
+		if string(output) != expected {
+			t.Errorf("Unexpected JSON output:\n%s", output)
+		}
+		if len(result.Redactions) != 3 {
+			t.Errorf("Expected three redactions, got %+v", result.Redactions)
+		}
+	})
+
+	t.Run("Schema values use the request", func(t *testing.T) {
+		output, _, err := engine.RedactJSON(ctx, []byte(`{"customer":{"ssn":"123-45-6789"}}`), JSONOptions{
+			Request: &Request{
+				Mode: ModeReplace,
+				ReplacerFunc: func(redaction Redaction) string {
+					return "<" + string(redaction.Type) + ">"
+				},
+			},
+			Schema: map[string]Type{"customer.ssn": TypeSSN},
+		})
+		if err != nil {
+			t.Fatalf("RedactJSON failed: %v", err)
+		}
+		if string(output) != `{"customer":{"ssn":"<ssn>"}}` {
+			t.Errorf("Expected the request's replacer to be used, got %s", output)
+		}
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		if _, _, err := engine.RedactJSON(ctx, []byte(`{"a": 1} {"b": 2}`), JSONOptions{}); err == nil {
+			t.Error("Expected trailing data to be rejected")
+		}
+		engine.SetStrictFields(true)
+		_, _, err := engine.RedactJSON(ctx, []byte(`{"ssn":"unknown"}`), JSONOptions{Schema: map[string]Type{"ssn": TypeSSN}})
+		if !errors.Is(err, ErrFieldMismatch) {
+			t.Errorf("Expected ErrFieldMismatch under strict fields, got %v", err)
+		}
+	})
+}
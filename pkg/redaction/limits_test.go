@@ -0,0 +1,56 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPatternLimits(t *testing.T) {
+	engine := NewEngine()
+	request := func(custom int) *Request {
+		patterns := make([]CustomPattern, custom)
+		for i := range patterns {
+			patterns[i] = CustomPattern{Name: fmt.Sprintf("p%d", i), Pattern: fmt.Sprintf(`ID-%d\b`, i)}
+		}
+		return &Request{Text: "ID-3 and ID-7", Mode: ModeReplace, CustomPatterns: patterns}
+	}
+
+	t.Run("default custom limit", func(t *testing.T) {
+		if _, err := engine.RedactText(context.Background(), request(defaultMaxCustomPatterns)); err != nil {
+			t.Fatalf("Expected %d custom patterns to be allowed, got %v", defaultMaxCustomPatterns, err)
+		}
+		_, err := engine.RedactText(context.Background(), request(defaultMaxCustomPatterns+1))
+		if !errors.Is(err, ErrTooManyPatterns) {
+			t.Errorf("Expected ErrTooManyPatterns, got %v", err)
+		}
+	})
+
+	t.Run("configured limits", func(t *testing.T) {
+		engine := NewEngine()
+		engine.SetPatternLimits(5, 0)
+		if _, err := engine.RedactText(context.Background(), request(6)); !errors.Is(err, ErrTooManyPatterns) {
+			t.Errorf("Expected the lowered custom limit to apply, got %v", err)
+		}
+
+		// The total counts the engine's built-in patterns too
+		builtIn := engine.GetRedactionStats()["active_patterns"].(int)
+		engine.SetPatternLimits(0, builtIn+2)
+		result, err := engine.RedactText(context.Background(), request(2))
+		if err != nil {
+			t.Fatalf("Expected the request to fit the total limit, got %v", err)
+		}
+		if len(result.Redactions) != 0 {
+			t.Errorf("Expected no matches for patterns ID-0 and ID-1, got %v", getRedactionTypes(result.Redactions))
+		}
+		if _, err := engine.RedactText(context.Background(), request(3)); !errors.Is(err, ErrTooManyPatterns) {
+			t.Errorf("Expected the total limit to apply, got %v", err)
+		}
+
+		engine.SetPatternLimits(0, 0)
+		if _, err := engine.RedactText(context.Background(), request(defaultMaxCustomPatterns+1)); err != nil {
+			t.Errorf("Expected no limit, got %v", err)
+		}
+	})
+}
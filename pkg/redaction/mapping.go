@@ -0,0 +1,55 @@
+package redaction
+
+import (
+	"sort"
+	"strings"
+)
+
+// buildMapping maps each replacement to the original it stands for.
+// Replacements shared by different originals, such as the placeholders of
+// replace mode, cannot be reversed and are left out; use tokenize mode, hash
+// mode or a strategy with unique output when every value must be reversible.
+func buildMapping(redactions []Redaction) map[string]string {
+	mapping := make(map[string]string, len(redactions))
+	ambiguous := make(map[string]bool)
+
+	for _, redaction := range redactions {
+		if redaction.Replacement == "" || ambiguous[redaction.Replacement] {
+			continue
+		}
+		if original, exists := mapping[redaction.Replacement]; exists && original != redaction.Original {
+			delete(mapping, redaction.Replacement)
+			ambiguous[redaction.Replacement] = true
+			continue
+		}
+		mapping[redaction.Replacement] = redaction.Original
+	}
+
+	return mapping
+}
+
+// ApplyMapping reverses a redacted text with a Result.Mapping, replacing every
+// mapped replacement with its original. Longer replacements are matched first
+// so that one replacement containing another is restored whole.
+func ApplyMapping(text string, mapping map[string]string) string {
+	if len(mapping) == 0 {
+		return text
+	}
+
+	replacements := make([]string, 0, len(mapping))
+	for replacement := range mapping {
+		replacements = append(replacements, replacement)
+	}
+	sort.Slice(replacements, func(i, j int) bool {
+		if len(replacements[i]) != len(replacements[j]) {
+			return len(replacements[i]) > len(replacements[j])
+		}
+		return replacements[i] < replacements[j]
+	})
+
+	pairs := make([]string, 0, 2*len(replacements))
+	for _, replacement := range replacements {
+		pairs = append(pairs, replacement, mapping[replacement])
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
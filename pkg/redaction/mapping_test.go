@@ -0,0 +1,48 @@
+package redaction
+
+import (
+	"context"
+	"testing"
+)
+
+func TestResultMapping(t *testing.T) {
+	ctx := context.Background()
+	engine := NewEngine()
+	text := "Mail a@example.com or b@example.com, SSN 123-45-6789, again a@example.com"
+
+	t.Run("Off by default", func(t *testing.T) {
+		result, err := engine.RedactText(ctx, &Request{Text: text, Mode: ModeTokenize})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		if result.Mapping != nil {
+			t.Errorf("Expected no mapping unless requested, got %v", result.Mapping)
+		}
+	})
+
+	t.Run("Mapping reverses the redacted text", func(t *testing.T) {
+		result, err := engine.RedactText(ctx, &Request{Text: text, Mode: ModeTokenize, IncludeMapping: true})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		if len(result.Mapping) != len(result.Redactions) {
+			t.Errorf("Expected one mapping entry per token, got %v", result.Mapping)
+		}
+		if restored := ApplyMapping(result.RedactedText, result.Mapping); restored != text {
+			t.Errorf("Expected the mapping to restore %q, got %q", text, restored)
+		}
+	})
+
+	t.Run("Shared placeholders are left out", func(t *testing.T) {
+		result, err := engine.RedactText(ctx, &Request{Text: text, Mode: ModeReplace, IncludeMapping: true})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		if _, exists := result.Mapping["[EMAIL_REDACTED]"]; exists {
+			t.Error("Expected the ambiguous email placeholder to be left out")
+		}
+		if result.Mapping["[SSN_REDACTED]"] != "123-45-6789" {
+			t.Errorf("Expected the unique SSN placeholder to be mapped, got %v", result.Mapping)
+		}
+	})
+}
@@ -0,0 +1,235 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// HTMLOptions configures RedactHTML
+type HTMLOptions struct {
+	// Request is the template each text node and scanned attribute is
+	// redacted with. Its Text is ignored.
+	Request *Request
+
+	// SkipElements lists elements whose content is never scanned
+	// (default: script, style)
+	SkipElements []string
+
+	// Attributes lists the attributes whose values are scanned
+	// (default: href, src, action, title, alt, value, content)
+	Attributes []string
+}
+
+// Defaults for HTMLOptions
+var (
+	defaultSkipElements = []string{"script", "style"}
+	defaultAttributes   = []string{"href", "src", "action", "title", "alt", "value", "content"}
+)
+
+// markupExcludedTypes are not redacted in attribute values: every href is a
+// link, so only what the URL carries (emails, tokens, webhooks) is redacted
+var markupExcludedTypes = map[Type]bool{TypeLink: true}
+
+// RedactHTML redacts the text nodes and selected attribute values of an HTML
+// document, leaving its tags and structure intact. Tokens that need no
+// redaction are written back byte for byte. Redaction offsets are relative to
+// the text node or attribute value they were found in.
+func (re *Engine) RedactHTML(ctx context.Context, document string, opts *HTMLOptions) (*Result, error) {
+	if opts == nil {
+		opts = &HTMLOptions{}
+	}
+	request := segmentRequest(opts.Request)
+	request.Text = document
+	if err := re.checkRequest(ctx, request, len(request.Text)); err != nil {
+		return nil, err
+	}
+
+	skip := toSet(opts.SkipElements, defaultSkipElements)
+	attributes := toSet(opts.Attributes, defaultAttributes)
+
+	result := &Result{OriginalText: document, Redactions: []Redaction{}, Timestamp: time.Now()}
+	var failure error
+	var output strings.Builder
+	skipDepth := 0
+
+	tokenizer := html.NewTokenizer(strings.NewReader(document))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			if err := tokenizer.Err(); !errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("error parsing HTML: %w", err)
+			}
+			break
+		}
+
+		raw := string(tokenizer.Raw())
+		token := tokenizer.Token()
+
+		switch tokenType {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if tokenType == html.StartTagToken && skip[token.Data] {
+				skipDepth++
+			}
+			changed := false
+			for i, attr := range token.Attr {
+				if !attributes[attr.Key] {
+					continue
+				}
+				redacted, err := re.redactSegment(ctx, request, attr.Val, markupExcludedTypes, result)
+				failure = firstError(failure, err)
+				if redacted != attr.Val {
+					token.Attr[i].Val = redacted
+					changed = true
+				}
+			}
+			if changed {
+				raw = token.String()
+			}
+		case html.EndTagToken:
+			if skip[token.Data] && skipDepth > 0 {
+				skipDepth--
+			}
+		case html.TextToken:
+			if skipDepth == 0 {
+				redacted, err := re.redactSegment(ctx, request, token.Data, nil, result)
+				failure = firstError(failure, err)
+				if redacted != token.Data {
+					raw = html.EscapeString(redacted)
+				}
+			}
+		}
+
+		output.WriteString(raw)
+	}
+
+	result.RedactedText = output.String()
+	return result, failure
+}
+
+// RedactMarkdown redacts Markdown text, leaving fenced code blocks (``` or ~~~)
+// unscanned. Redaction offsets are relative to the prose section they were
+// found in.
+func (re *Engine) RedactMarkdown(ctx context.Context, markdown string, template *Request) (*Result, error) {
+	request := segmentRequest(template)
+	request.Text = markdown
+	if err := re.checkRequest(ctx, request, len(request.Text)); err != nil {
+		return nil, err
+	}
+
+	result := &Result{OriginalText: markdown, Redactions: []Redaction{}, Timestamp: time.Now()}
+	var failure error
+	var output, prose strings.Builder
+	fence := ""
+
+	flushProse := func() {
+		if prose.Len() == 0 {
+			return
+		}
+		redacted, err := re.redactSegment(ctx, request, prose.String(), nil, result)
+		failure = firstError(failure, err)
+		output.WriteString(redacted)
+		prose.Reset()
+	}
+
+	for _, line := range strings.SplitAfter(markdown, "\n") {
+		marker := fenceMarker(line)
+		switch {
+		case fence == "" && marker != "":
+			flushProse()
+			fence = marker
+			output.WriteString(line)
+		case fence != "":
+			if marker == fence {
+				fence = ""
+			}
+			output.WriteString(line)
+		default:
+			prose.WriteString(line)
+		}
+	}
+	flushProse()
+
+	result.RedactedText = output.String()
+	return result, failure
+}
+
+// fenceMarker returns the code fence a line opens or closes, if any
+func fenceMarker(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return "" // Indented four or more spaces: code, not a fence
+	}
+	for _, marker := range []string{"```", "~~~"} {
+		if strings.HasPrefix(trimmed, marker) {
+			return marker
+		}
+	}
+	return ""
+}
+
+// segmentRequest copies the request template of a document redacted in
+// parts, such as the text nodes of an HTML page, the cells of a CSV file or
+// the chunks of a stream. The template's mode, strategy, types, options and
+// other settings apply to every part, while its Text is ignored by the
+// callers. Parts are not individually reversible, and a nil template
+// redacts in replace mode.
+func segmentRequest(template *Request) *Request {
+	request := &Request{Mode: ModeReplace}
+	if template != nil {
+		*request = *template
+	}
+	request.Reversible = false
+	return request
+}
+
+// redactSegment redacts one part of a larger document, adding its redactions
+// and non-fatal errors to result. Matches of excluded types are dropped
+// before overlaps are resolved, so the PII inside them is still found,
+// except shortened links under the redact_shortened_links option.
+// The returned error is set only when every detector failed.
+func (re *Engine) redactSegment(ctx context.Context, template *Request, text string, exclude map[Type]bool, result *Result) (string, error) {
+	if strings.TrimSpace(text) == "" {
+		return text, nil
+	}
+
+	request := *template
+	request.Text = text
+	keepShortened := optionBool(request.Options, redactShortenedLinksOption)
+	excluded := func(redaction Redaction) bool {
+		return exclude[redaction.Type] && !(keepShortened && isShortenedLink(redaction))
+	}
+	kept, candidates, errs := re.detectRedactions(ctx, stringSource(text), &request, excluded)
+	re.recordMatches(kept)
+	setRuneOffsets(text, kept, candidates)
+
+	result.Redactions = append(result.Redactions, kept...)
+	result.Candidates = append(result.Candidates, candidates...)
+	result.Errors = append(result.Errors, errorStrings(errs)...)
+	return applyRedactionsToText(text, kept), re.detectorFailure(errs)
+}
+
+// firstError keeps the first of a series of errors
+func firstError(current, next error) error {
+	if current != nil {
+		return current
+	}
+	return next
+}
+
+// toSet builds a lookup set from values, or from defaults when values is empty
+func toSet(values, defaults []string) map[string]bool {
+	if len(values) == 0 {
+		values = defaults
+	}
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[strings.ToLower(value)] = true
+	}
+	return set
+}
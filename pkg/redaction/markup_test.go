@@ -0,0 +1,74 @@
+package redaction
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRedactHTML(t *testing.T) {
+	engine := NewEngine()
+	document := `<div class="card" data-id="42"><p>Contact <b>john@example.com</b> today</p>` +
+		`<a href="mailto:john@example.com" title="Email">write</a>` +
+		`<a href="https://example.com/docs">docs</a>` +
+		`<script>var admin = "root@example.com";</script></div>`
+
+	result, err := engine.RedactHTML(context.Background(), document, nil)
+	if err != nil {
+		t.Fatalf("RedactHTML failed: %v", err)
+	}
+
+	expected := `<div class="card" data-id="42"><p>Contact <b>[EMAIL_REDACTED]</b> today</p>` +
+		`<a href="mailto:[EMAIL_REDACTED]" title="Email">write</a>` +
+		`<a href="https://example.com/docs">docs</a>` +
+		`<script>var admin = "root@example.com";</script></div>`
+	if result.RedactedText != expected {
+		t.Errorf("Unexpected redacted HTML:\n got: %s\nwant: %s", result.RedactedText, expected)
+	}
+	if len(result.Redactions) != 2 {
+		t.Errorf("Expected 2 redactions, got %+v", result.Redactions)
+	}
+
+	// Entities in text nodes are decoded for matching and re-escaped
+	result, err = engine.RedactHTML(context.Background(), `<p>Tom &amp; Jerry: 123-45-6789</p>`, nil)
+	if err != nil {
+		t.Fatalf("RedactHTML failed: %v", err)
+	}
+	if result.RedactedText != `<p>Tom &amp; Jerry: [SSN_REDACTED]</p>` {
+		t.Errorf("Unexpected redacted HTML: %s", result.RedactedText)
+	}
+}
+
+func TestRedactHTMLLinkQueryString(t *testing.T) {
+	engine := NewEngine()
+	document := `<a href="https://example.com/unsubscribe?email=john@x.com">unsubscribe</a>`
+
+	result, err := engine.RedactHTML(context.Background(), document, nil)
+	if err != nil {
+		t.Fatalf("RedactHTML failed: %v", err)
+	}
+
+	expected := `<a href="https://example.com/unsubscribe?email=[EMAIL_REDACTED]">unsubscribe</a>`
+	if result.RedactedText != expected {
+		t.Errorf("Unexpected redacted HTML:\n got: %s\nwant: %s", result.RedactedText, expected)
+	}
+}
+
+func TestRedactMarkdown(t *testing.T) {
+	engine := NewEngine()
+	markdown := "Mail john@example.com for access.\n\n" +
+		"```sh\ncurl -u admin@example.com https://api.example.com\n```\n\n" +
+		"Or call 555-123-4567.\n"
+
+	result, err := engine.RedactMarkdown(context.Background(), markdown, nil)
+	if err != nil {
+		t.Fatalf("RedactMarkdown failed: %v", err)
+	}
+
+	if strings.Contains(result.RedactedText, "john@example.com") || strings.Contains(result.RedactedText, "555-123-4567") {
+		t.Errorf("Expected prose to be redacted, got %s", result.RedactedText)
+	}
+	if !strings.Contains(result.RedactedText, "curl -u admin@example.com https://api.example.com") {
+		t.Errorf("Expected the code fence to be left alone, got %s", result.RedactedText)
+	}
+}
@@ -0,0 +1,70 @@
+package redaction
+
+import (
+	"unicode"
+)
+
+// mergeAdjacentOption merges consecutive redactions of the same type that are
+// separated only by punctuation or whitespace. Its value is the longest
+// separator merged, in runes; true merges separators of up to
+// defaultMergeGap runes such as ", ".
+const mergeAdjacentOption = "merge_adjacent"
+
+// defaultMergeGap is the separator length merged when merge_adjacent is true
+const defaultMergeGap = 2
+
+// mergeGap returns the longest separator the request merges across, or zero
+// when adjacent redactions are kept separate
+func mergeGap(options map[string]interface{}) int {
+	if optionBool(options, mergeAdjacentOption) {
+		return defaultMergeGap
+	}
+	return optionInt(options, mergeAdjacentOption)
+}
+
+// mergeAdjacent merges runs of same-type redactions whose separators are at
+// most gap runes long and hold no letters or digits. redactions must be
+// sorted by start position. Merged spans cover their separators and get a
+// fresh replacement for the merged text.
+func (re *Engine) mergeAdjacent(src matchSource, redactions []Redaction, gap int) []Redaction {
+	if len(redactions) < 2 {
+		return redactions
+	}
+
+	merged := []Redaction{redactions[0]}
+	for _, next := range redactions[1:] {
+		last := &merged[len(merged)-1]
+		if last.Type != next.Type || !isMergeableGap(src.slice(last.End, next.Start), gap) {
+			merged = append(merged, next)
+			continue
+		}
+
+		last.End = next.End
+		last.Original = src.slice(last.Start, last.End)
+		last.Replacement = re.generateReplacement(last.Type, last.Original)
+		last.Confidence = minFloat(last.Confidence, next.Confidence)
+		last.Context = re.extractSourceContext(src, last.Start, last.End)
+	}
+	return merged
+}
+
+// isMergeableGap reports whether a separator is at most gap runes long and
+// holds no letters or digits
+func isMergeableGap(separator string, gap int) bool {
+	runes := 0
+	for _, r := range separator {
+		runes++
+		if runes > gap || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// minFloat returns the smaller of two floats
+func minFloat(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,329 @@
+package redaction
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/censgate/redact/pkg/strategies"
+)
+
+// hashPrefix, encryptPrefix and signaturePrefix mark the replacements of the
+// hash, encrypt and signature modes
+const (
+	hashPrefix      = "[HASH:"
+	encryptPrefix   = "[ENC:"
+	signaturePrefix = "[SIG:"
+)
+
+// SetHashSalt sets the secret salt keying hash-mode replacements
+func (re *Engine) SetHashSalt(salt string) {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.hashSalt = salt
+}
+
+// SetEncryptionKey sets the AES key used by encrypt mode. The key must be
+// 16, 24 or 32 bytes long.
+func (re *Engine) SetEncryptionKey(key []byte) error {
+	if _, err := aes.NewCipher(key); err != nil {
+		return fmt.Errorf("invalid encryption key: %v", err)
+	}
+
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.encryptionKey = append([]byte(nil), key...)
+	return nil
+}
+
+// SetSignatureKey sets the secret key of signature mode. Engines sharing a
+// key produce the same signature for the same value.
+func (re *Engine) SetSignatureKey(key []byte) error {
+	if len(key) == 0 {
+		return fmt.Errorf("signature key cannot be empty")
+	}
+
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.signatureKey = append([]byte(nil), key...)
+	return nil
+}
+
+// validModes are the modes a request or policy rule may use
+var validModes = []Mode{ModeReplace, ModeMask, ModeRemove, ModeTokenize, ModeHash, ModeEncrypt, ModeSignature, ModeLabelHash}
+
+// implementedModes are the valid modes with their own replacement. The others
+// are accepted but fall back to the replace placeholder.
+var implementedModes = []Mode{ModeReplace, ModeMask, ModeRemove, ModeTokenize, ModeHash, ModeEncrypt, ModeSignature, ModeLabelHash}
+
+// isValidMode reports whether mode is one of validModes
+func isValidMode(mode Mode) bool {
+	for _, validMode := range validModes {
+		if mode == validMode {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMode defaults an empty request mode to replace and rejects unknown
+// modes and modes whose secret has not been set, including those of the
+// request's type modes
+func (re *Engine) checkMode(request *Request) error {
+	if request.Mode == "" {
+		request.Mode = ModeReplace
+	}
+	if !isValidMode(request.Mode) {
+		return fmt.Errorf("invalid redaction mode: %q (valid modes: %v)", request.Mode, validModes)
+	}
+	if err := re.checkModeSecrets(request.Mode); err != nil {
+		return err
+	}
+	for redactionType, mode := range request.TypeModes {
+		if !isValidMode(mode) {
+			return fmt.Errorf("invalid redaction mode for type %s: %q (valid modes: %v)", redactionType, mode, validModes)
+		}
+		if err := re.checkModeSecrets(mode); err != nil {
+			return fmt.Errorf("type %s: %w", redactionType, err)
+		}
+	}
+	return nil
+}
+
+// checkModeSecrets fails when a mode needs a secret that has not been set
+func (re *Engine) checkModeSecrets(mode Mode) error {
+	re.mutex.RLock()
+	defer re.mutex.RUnlock()
+
+	switch {
+	case (mode == ModeHash || mode == ModeLabelHash) && re.hashSalt == "":
+		return fmt.Errorf("%s mode requires a hash salt (set REDACT_STRATEGIES_HASH_SALT)", mode)
+	case mode == ModeEncrypt && len(re.encryptionKey) == 0:
+		return fmt.Errorf("encrypt mode requires an encryption key (set REDACT_ENCRYPTION_KEY)")
+	case mode == ModeSignature && len(re.signatureKey) == 0:
+		return fmt.Errorf("signature mode requires a signature key (set REDACT_STRATEGIES_SIGNATURE_KEY)")
+	default:
+		return nil
+	}
+}
+
+// hashValue returns the hash-mode replacement: a truncated HMAC-SHA256 of the
+// value keyed by the hash salt
+func (re *Engine) hashValue(value string) (string, error) {
+	digest, err := re.saltedHash(value)
+	if err != nil {
+		return "", err
+	}
+	return hashPrefix + digest[:16] + "]", nil
+}
+
+// labelHashValue returns the label_hash-mode replacement, such as
+// [EMAIL#ab12cd]: the type label, which stays readable, and the first six
+// hex characters of the salted hash, so recurrences of a value can be
+// matched up
+func (re *Engine) labelHashValue(redactionType Type, value string) (string, error) {
+	digest, err := re.saltedHash(value)
+	if err != nil {
+		return "", err
+	}
+	return "[" + strings.ToUpper(string(redactionType)) + "#" + digest[:6] + "]", nil
+}
+
+// saltedHash returns the hex HMAC-SHA256 of value keyed by the hash salt
+func (re *Engine) saltedHash(value string) (string, error) {
+	re.mutex.RLock()
+	salt := re.hashSalt
+	re.mutex.RUnlock()
+
+	if salt == "" {
+		return "", fmt.Errorf("hash mode requires a hash salt")
+	}
+
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// signValue returns the signature-mode replacement: a truncated HMAC-SHA256
+// of the normalized value keyed by the signature key, so formatting
+// differences such as "Jane@Example.com" and "jane@example.com" sign alike
+func (re *Engine) signValue(value string) (string, error) {
+	re.mutex.RLock()
+	key := re.signatureKey
+	re.mutex.RUnlock()
+
+	if len(key) == 0 {
+		return "", fmt.Errorf("signature mode requires a signature key")
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signatureValue(value)))
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))[:12] + "]", nil
+}
+
+// signatureValue normalizes a value for signing: case is folded and
+// whitespace and the separators used in formatted numbers are dropped
+func signatureValue(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsSpace(r), r == '-', r == '(', r == ')':
+			return -1
+		default:
+			return unicode.ToLower(r)
+		}
+	}, value)
+}
+
+// maskRune replaces each letter and digit in mask mode
+const maskRune = '*'
+
+// maskValue returns the mask-mode replacement: the value with every letter
+// and digit masked and other characters kept, so jane@example.com becomes
+// ****@*******.***
+func maskValue(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return maskRune
+		}
+		return r
+	}, value)
+}
+
+// identityValue returns the value hashed for a redaction. Email subaddresses
+// are stripped when the request asks for plus-address normalization.
+func identityValue(request *Request, redaction *Redaction) string {
+	if redaction.Type != TypeEmail {
+		return redaction.Original
+	}
+	if normalize, ok := request.Options[strategies.NormalizePlusAddressOption].(bool); ok && normalize {
+		return strategies.NormalizePlusAddress(redaction.Original)
+	}
+	return redaction.Original
+}
+
+// encryptValue returns the encrypt-mode replacement: the value sealed with
+// AES-GCM under a random nonce, base64 encoded
+func (re *Engine) encryptValue(value string) (string, error) {
+	gcm, err := re.cipher()
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %v", err)
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(value), nil)
+	return encryptPrefix + base64.RawURLEncoding.EncodeToString(sealed) + "]", nil
+}
+
+// DecryptValue reverses an encrypt-mode replacement using the engine's key
+func (re *Engine) DecryptValue(replacement string) (string, error) {
+	if !strings.HasPrefix(replacement, encryptPrefix) || !strings.HasSuffix(replacement, "]") {
+		return "", fmt.Errorf("not an encrypted replacement")
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(replacement[len(encryptPrefix) : len(replacement)-1])
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted replacement: %v", err)
+	}
+
+	gcm, err := re.cipher()
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("invalid encrypted replacement: too short")
+	}
+
+	plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt replacement: %v", err)
+	}
+	return string(plain), nil
+}
+
+// cipher builds the AES-GCM cipher for the configured key
+func (re *Engine) cipher() (cipher.AEAD, error) {
+	re.mutex.RLock()
+	key := re.encryptionKey
+	re.mutex.RUnlock()
+
+	if len(key) == 0 {
+		return nil, fmt.Errorf("encrypt mode requires an encryption key")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %v", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// collapseWhitespaceOption tidies the text around ModeRemove deletions
+const collapseWhitespaceOption = "collapse_whitespace"
+
+// applyRedactionsWithOptions applies redactions to text, collapsing the
+// whitespace left around removed spans when the request asks for it
+func applyRedactionsWithOptions(text string, redactions []Redaction, request *Request) string {
+	if !optionBool(request.Options, collapseWhitespaceOption) {
+		return applyRedactionsToText(text, redactions)
+	}
+	return applyRedactionsCollapsing(text, redactions)
+}
+
+// applyRedactionsCollapsing applies redactions like applyRedactionsToText, but
+// where a span was removed it drops the whitespace that would otherwise be
+// doubled and any space left before closing punctuation. Text away from
+// removed spans is copied unchanged.
+func applyRedactionsCollapsing(text string, redactions []Redaction) string {
+	ordered := make([]Redaction, len(redactions))
+	copy(ordered, redactions)
+	sortRedactionsAscending(ordered)
+
+	output := make([]byte, 0, len(text))
+	removed := false
+	writeSegment := func(segment string) {
+		if removed {
+			if len(output) == 0 || isSpace(output[len(output)-1]) {
+				segment = strings.TrimLeft(segment, " \t")
+			}
+			if segment != "" && strings.ContainsRune(".,;:!?)]}", rune(segment[0])) {
+				output = bytes.TrimRight(output, " \t")
+			}
+		}
+		output = append(output, segment...)
+		removed = removed && segment == ""
+	}
+
+	last := 0
+	for _, redaction := range ordered {
+		writeSegment(text[last:redaction.Start])
+		if redaction.Replacement == "" {
+			removed = true
+		} else {
+			writeSegment(redaction.Replacement)
+		}
+		last = redaction.End
+	}
+	writeSegment(text[last:])
+
+	return string(output)
+}
+
+// isSpace reports whether b is a space or tab
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t'
+}
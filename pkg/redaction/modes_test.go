@@ -0,0 +1,280 @@
+package redaction
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/censgate/redact/pkg/strategies"
+)
+
+func TestSecretModes(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Missing secrets fail loudly", func(t *testing.T) {
+		engine := NewEngine()
+		for _, mode := range []Mode{ModeHash, ModeEncrypt, ModeSignature, ModeLabelHash} {
+			if _, err := engine.RedactText(ctx, &Request{Text: "SSN 123-45-6789", Mode: mode}); err == nil {
+				t.Errorf("Expected %s mode to fail without its secret", mode)
+			}
+		}
+	})
+
+	t.Run("Hash mode is keyed by the salt", func(t *testing.T) {
+		engine := NewEngine()
+		engine.SetHashSalt("salt-a")
+
+		first, err := engine.RedactText(ctx, &Request{Text: "a@example.com a@example.com", Mode: ModeHash})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		if first.Redactions[0].Replacement != first.Redactions[1].Replacement ||
+			!strings.HasPrefix(first.Redactions[0].Replacement, "[HASH:") {
+			t.Errorf("Expected equal hash replacements, got %+v", first.Redactions)
+		}
+
+		engine.SetHashSalt("salt-b")
+		second, err := engine.RedactText(ctx, &Request{Text: "a@example.com", Mode: ModeHash})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		if second.Redactions[0].Replacement == first.Redactions[0].Replacement {
+			t.Error("Expected a different salt to change the hash")
+		}
+	})
+
+	t.Run("Label hash mode keeps the type readable", func(t *testing.T) {
+		engine := NewEngine()
+		engine.SetHashSalt("salt-a")
+
+		result, err := engine.RedactText(ctx, &Request{
+			Text: "a@example.com, b@example.com, a@example.com, SSN 123-45-6789",
+			Mode: ModeLabelHash,
+		})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		if len(result.Redactions) != 4 {
+			t.Fatalf("Expected four redactions, got %+v", result.Redactions)
+		}
+
+		first, second, repeat, ssn := result.Redactions[0].Replacement, result.Redactions[1].Replacement,
+			result.Redactions[2].Replacement, result.Redactions[3].Replacement
+		if !strings.HasPrefix(first, "[EMAIL#") || len(first) != len("[EMAIL#]")+6 {
+			t.Errorf("Unexpected label hash format: %q", first)
+		}
+		if repeat != first {
+			t.Errorf("Expected the same email to get the same suffix, got %q and %q", first, repeat)
+		}
+		if second == first || !strings.HasPrefix(second, "[EMAIL#") {
+			t.Errorf("Expected a different email to get a different suffix, got %q and %q", first, second)
+		}
+		if !strings.HasPrefix(ssn, "[SSN#") {
+			t.Errorf("Expected the SSN label, got %q", ssn)
+		}
+	})
+
+	t.Run("Signature mode links records across files", func(t *testing.T) {
+		sign := func(key, text string) []Redaction {
+			engine := NewEngine()
+			if err := engine.SetSignatureKey([]byte(key)); err != nil {
+				t.Fatalf("SetSignatureKey failed: %v", err)
+			}
+			result, err := engine.RedactText(ctx, &Request{Text: text, Mode: ModeSignature})
+			if err != nil {
+				t.Fatalf("RedactText failed: %v", err)
+			}
+			return result.Redactions
+		}
+
+		first := sign("shared-key", "Patient SSN 123-45-6789, contact 234-56-7890 or jane.doe@example.com")
+		second := sign("shared-key", "Claim filed under SSN 123-45-6789 by Jane.Doe@Example.com")
+		if len(first) != 3 || len(second) != 2 {
+			t.Fatalf("Expected SSNs in both files, got %v and %v", getRedactionTypes(first), getRedactionTypes(second))
+		}
+
+		signature := first[0].Replacement
+		if len(signature) != len("[SIG:]")+12 || !strings.HasPrefix(signature, "[SIG:") {
+			t.Errorf("Unexpected signature format: %q", signature)
+		}
+		if second[0].Replacement != signature {
+			t.Errorf("Expected the same SSN to sign alike across files, got %q and %q", signature, second[0].Replacement)
+		}
+		if first[1].Replacement == signature {
+			t.Error("Expected different SSNs to sign differently")
+		}
+		if second[1].Replacement != first[2].Replacement {
+			t.Errorf("Expected emails differing only in case to sign alike, got %q and %q", first[2].Replacement, second[1].Replacement)
+		}
+		if other := sign("other-key", "SSN 123-45-6789"); other[0].Replacement == signature {
+			t.Error("Expected a different key to change the signature")
+		}
+
+		if err := NewEngine().SetSignatureKey(nil); err == nil {
+			t.Error("Expected an empty signature key to be rejected")
+		}
+	})
+
+	t.Run("Encrypt mode round trips", func(t *testing.T) {
+		engine := NewEngine()
+		if err := engine.SetEncryptionKey([]byte("short")); err == nil {
+			t.Error("Expected an invalid key length to be rejected")
+		}
+		if err := engine.SetEncryptionKey([]byte("0123456789abcdef")); err != nil {
+			t.Fatalf("SetEncryptionKey failed: %v", err)
+		}
+
+		result, err := engine.RedactText(ctx, &Request{Text: "SSN 123-45-6789", Mode: ModeEncrypt})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		if strings.Contains(result.RedactedText, "123-45-6789") {
+			t.Fatalf("Plaintext leaked: %s", result.RedactedText)
+		}
+
+		plain, err := engine.DecryptValue(result.Redactions[0].Replacement)
+		if err != nil || plain != "123-45-6789" {
+			t.Errorf("Expected decryption to recover the SSN, got %q (%v)", plain, err)
+		}
+	})
+}
+
+func TestPlusAddressNormalization(t *testing.T) {
+	ctx := context.Background()
+	engine := NewEngine()
+	engine.SetHashSalt("salt")
+	text := "From john+newsletter@acme.com and john@acme.com"
+
+	replacements := func(request *Request) []string {
+		t.Helper()
+		result, err := engine.RedactText(ctx, request)
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		var values []string
+		for _, redaction := range result.Redactions {
+			if redaction.Type == TypeEmail {
+				values = append(values, redaction.Replacement)
+			}
+		}
+		if len(values) != 2 {
+			t.Fatalf("Expected both addresses detected as email, got %+v", result.Redactions)
+		}
+		return values
+	}
+
+	normalize := map[string]interface{}{strategies.NormalizePlusAddressOption: true}
+	for name, request := range map[string]*Request{
+		"hash mode":       {Text: text, Mode: ModeHash},
+		"consistent hash": {Text: text, Mode: ModeReplace, Strategy: "consistent_hash"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if values := replacements(request); values[0] == values[1] {
+				t.Errorf("Expected distinct identities without normalization, got %v", values)
+			}
+
+			request.Options = normalize
+			if values := replacements(request); values[0] != values[1] {
+				t.Errorf("Expected a shared identity with normalization, got %v", values)
+			}
+		})
+	}
+}
+
+func TestRemoveModeCollapseWhitespace(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+	text := "Contact jane@example.com at 555-123-4567 .  Thanks,\n  Jane"
+
+	raw, err := engine.RedactText(ctx, &Request{Text: text, Mode: ModeRemove})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+	if raw.RedactedText != "Contact  at  .  Thanks,\n  Jane" {
+		t.Errorf("Unexpected raw removal output: %q", raw.RedactedText)
+	}
+
+	collapsed, err := engine.RedactText(ctx, &Request{
+		Text:    text,
+		Mode:    ModeRemove,
+		Options: map[string]interface{}{"collapse_whitespace": true},
+	})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+	// Whitespace away from the removals, after "." and inside "Thanks,\n  Jane", is untouched
+	if collapsed.RedactedText != "Contact at.  Thanks,\n  Jane" {
+		t.Errorf("Unexpected collapsed output: %q", collapsed.RedactedText)
+	}
+}
+
+func TestImplementedModes(t *testing.T) {
+	engine := NewEngine()
+	engine.SetHashSalt("test-salt")
+	if err := engine.SetEncryptionKey([]byte("0123456789abcdef")); err != nil {
+		t.Fatalf("SetEncryptionKey failed: %v", err)
+	}
+	if err := engine.SetSignatureKey([]byte("test-key")); err != nil {
+		t.Fatalf("SetSignatureKey failed: %v", err)
+	}
+	ctx := context.Background()
+
+	caps := engine.GetCapabilities()
+	implemented := make(map[Mode]bool)
+	for _, mode := range caps.ImplementedModes {
+		implemented[mode] = true
+	}
+
+	// A mode is implemented exactly when it does not degrade to the replace placeholder
+	for _, mode := range caps.SupportedModes {
+		result, err := engine.RedactText(ctx, &Request{Text: "jane@example.com", Mode: mode})
+		if err != nil {
+			t.Fatalf("RedactText in %s mode failed: %v", mode, err)
+		}
+		degraded := mode != ModeReplace && result.RedactedText == "[EMAIL_REDACTED]"
+		if implemented[mode] == degraded {
+			t.Errorf("Mode %s: implemented=%v but output was %q", mode, implemented[mode], result.RedactedText)
+		}
+	}
+}
+
+func TestTypeModes(t *testing.T) {
+	engine := NewEngine()
+	if err := engine.SetEncryptionKey([]byte("0123456789abcdef")); err != nil {
+		t.Fatalf("SetEncryptionKey failed: %v", err)
+	}
+	ctx := context.Background()
+
+	result, err := engine.RedactText(ctx, &Request{
+		Text:      "SSN 123-45-6789, email jane@example.com, IP 192.168.1.10",
+		Mode:      ModeReplace,
+		TypeModes: map[Type]Mode{TypeSSN: ModeEncrypt, TypeEmail: ModeMask},
+	})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+
+	replacements := make(map[Type]string)
+	for _, redaction := range result.Redactions {
+		replacements[redaction.Type] = redaction.Replacement
+	}
+
+	if !strings.HasPrefix(replacements[TypeSSN], encryptPrefix) {
+		t.Errorf("Expected the SSN encrypted, got %q", replacements[TypeSSN])
+	}
+	if plain, err := engine.DecryptValue(replacements[TypeSSN]); err != nil || plain != "123-45-6789" {
+		t.Errorf("Expected the SSN to decrypt, got %q, %v", plain, err)
+	}
+	if replacements[TypeEmail] != "****@*******.***" {
+		t.Errorf("Expected the email masked, got %q", replacements[TypeEmail])
+	}
+	if replacements[TypeIPAddress] != "[IP_ADDRESS_REDACTED]" {
+		t.Errorf("Expected the IP address in the default mode, got %q", replacements[TypeIPAddress])
+	}
+
+	// A type mode's secret is checked like the request mode's
+	_, err = engine.RedactText(ctx, &Request{Text: "jane@example.com", TypeModes: map[Type]Mode{TypeEmail: ModeHash}})
+	if err == nil || !strings.Contains(err.Error(), "hash salt") {
+		t.Errorf("Expected a missing hash salt error, got %v", err)
+	}
+}
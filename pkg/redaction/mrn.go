@@ -0,0 +1,55 @@
+package redaction
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// defaultMRNPattern matches a medical record number of 6-10 letters and
+// digits after an MRN or medical record number keyword, which is redacted
+// with the number
+const defaultMRNPattern = `(?i:\b(?:MRN|Medical\s+Record\s+(?:No\.?|Number|#))\s*[:#]?\s*[A-Z0-9]{6,10}\b)`
+
+// facilityFormat is a facility's own MRN format
+type facilityFormat struct {
+	name    string
+	pattern string
+}
+
+// mrnPattern joins the keyword-guarded default with the facility formats
+func mrnPattern(formats []facilityFormat) *regexp.Regexp {
+	alternatives := []string{defaultMRNPattern}
+	for _, format := range formats {
+		alternatives = append(alternatives, `(?:`+format.pattern+`)`)
+	}
+	return regexp.MustCompile(strings.Join(alternatives, "|"))
+}
+
+// AddFacilityMRNFormat registers a facility's MRN format, such as
+// `\bMGH-\d{7}\b`, detected as TypeMRN without a keyword. Adding a format
+// under an existing name replaces it.
+func (re *Engine) AddFacilityMRNFormat(name, pattern string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("facility name cannot be empty")
+	}
+	if _, err := compileUserPattern(pattern); err != nil {
+		return err
+	}
+
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	formats := make([]facilityFormat, 0, len(re.mrnFormats)+1)
+	for _, format := range re.mrnFormats {
+		if format.name != name {
+			formats = append(formats, format)
+		}
+	}
+	formats = append(formats, facilityFormat{name: name, pattern: pattern})
+
+	re.mrnFormats = formats
+	re.patterns[TypeMRN] = mrnPattern(formats)
+	re.invalidateCombinedScan()
+	return nil
+}
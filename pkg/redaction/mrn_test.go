@@ -0,0 +1,105 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestMRNs tests keyword-guarded medical record number detection
+func TestMRNs(t *testing.T) {
+	engine := NewEngine()
+
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"MRN keyword", "Patient MRN: 00482913 admitted", "MRN: 00482913"},
+		{"Medical record number keyword", "Medical Record Number A1234567 updated", "Medical Record Number A1234567"},
+		{"Medical record # keyword", "medical record # 7H3K9Q2 on file", "medical record # 7H3K9Q2"},
+		{"Bare number", "Reference 00482913 attached", ""},
+		{"Too short", "MRN: 12345", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := engine.RedactText(context.Background(), &Request{
+				Text: tc.text,
+				Mode: ModeReplace,
+			})
+			if err != nil {
+				t.Fatalf("RedactText failed: %v", err)
+			}
+
+			var found []string
+			for _, redaction := range result.Redactions {
+				if redaction.Type == TypeMRN {
+					found = append(found, redaction.Original)
+				}
+			}
+
+			if tc.expected == "" {
+				if len(found) != 0 {
+					t.Errorf("Expected no MRNs, got %v for text: %s", found, tc.text)
+				}
+				return
+			}
+			if len(found) != 1 || found[0] != tc.expected {
+				t.Errorf("Expected %q, got %v (all: %v)", tc.expected, found, getRedactionTypes(result.Redactions))
+			}
+			if !strings.Contains(result.RedactedText, "[MRN_REDACTED]") {
+				t.Errorf("Expected an MRN placeholder, got %s", result.RedactedText)
+			}
+		})
+	}
+}
+
+// TestFacilityMRNFormat tests that a registered facility format is detected
+// without a keyword
+func TestFacilityMRNFormat(t *testing.T) {
+	engine := NewEngine()
+	text := "Transferred from MGH-4829137 with MRN: 00482913"
+
+	redact := func() []string {
+		result, err := engine.RedactText(context.Background(), &Request{Text: text, Mode: ModeReplace})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		var found []string
+		for _, redaction := range result.Redactions {
+			if redaction.Type == TypeMRN {
+				found = append(found, redaction.Original)
+			}
+		}
+		return found
+	}
+
+	if found := redact(); len(found) != 1 {
+		t.Fatalf("Expected only the keyworded MRN before the format is added, got %v", found)
+	}
+
+	if err := engine.AddFacilityMRNFormat("MGH", `\bMGH-\d{7}\b`); err != nil {
+		t.Fatalf("AddFacilityMRNFormat failed: %v", err)
+	}
+	found := redact()
+	if len(found) != 2 || found[0] != "MGH-4829137" || found[1] != "MRN: 00482913" {
+		t.Errorf("Expected the facility and keyworded MRNs, got %v", found)
+	}
+
+	// Re-adding a facility replaces its format
+	if err := engine.AddFacilityMRNFormat("MGH", `\bMGH-\d{8}\b`); err != nil {
+		t.Fatalf("AddFacilityMRNFormat failed: %v", err)
+	}
+	if found := redact(); len(found) != 1 {
+		t.Errorf("Expected the replaced format not to match, got %v", found)
+	}
+
+	if err := engine.AddFacilityMRNFormat("BAD", `MGH-(\d{7}`); !errors.Is(err, ErrInvalidPattern) {
+		t.Errorf("Expected ErrInvalidPattern, got %v", err)
+	}
+	if err := engine.AddFacilityMRNFormat("", `\d{7}`); err == nil {
+		t.Error("Expected an error for an empty facility name")
+	}
+}
@@ -0,0 +1,124 @@
+package redaction
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// normalizeOption is the request option that enables the normalization pass:
+// zero-width characters are stripped and confusable homoglyphs folded to ASCII
+// before detection, defeating obfuscated values such as "jo\u200bhn@acme.com"
+const normalizeOption = "normalize_unicode"
+
+// nfcOption is the request option that composes text to Unicode NFC before
+// detection, so a decomposed "e" + U+0301 matches like a precomposed "é"
+const nfcOption = "normalize_nfc"
+
+// zeroWidthRunes are invisible characters removed before detection
+var zeroWidthRunes = map[rune]bool{
+	'\u00ad': true, // Soft hyphen
+	'\u200b': true, // Zero-width space
+	'\u200c': true, // Zero-width non-joiner
+	'\u200d': true, // Zero-width joiner
+	'\u2060': true, // Word joiner
+	'\ufeff': true, // Zero-width no-break space
+}
+
+// confusableRunes folds Cyrillic and Greek look-alikes to the ASCII letters
+// they imitate. Fullwidth forms are folded separately.
+var confusableRunes = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'в': 'b', 'е': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o', 'р': 'p',
+	'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'і': 'i', 'ј': 'j', 'ѕ': 's', 'ԁ': 'd',
+	'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H', 'О': 'O', 'Р': 'P',
+	'С': 'C', 'Т': 'T', 'У': 'Y', 'Х': 'X', 'І': 'I', 'Ј': 'J', 'Ѕ': 'S',
+	// Greek
+	'α': 'a', 'ε': 'e', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'τ': 't',
+	'υ': 'u', 'χ': 'x', 'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I',
+	'Κ': 'K', 'Μ': 'M', 'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+}
+
+// normalizedText is text after the normalization pass, with the offsets
+// needed to map spans in it back onto the original text
+type normalizedText struct {
+	text   string
+	starts []int // Original start offset of the rune each normalized byte came from
+	ends   []int // Original end offset of the rune each normalized byte came from
+}
+
+// normalizeText composes original to NFC when compose is set, then strips
+// zero-width characters and folds confusables to ASCII when fold is set
+func normalizeText(original string, fold, compose bool) *normalizedText {
+	var builder strings.Builder
+	normalized := &normalizedText{
+		starts: make([]int, 0, len(original)),
+		ends:   make([]int, 0, len(original)),
+	}
+
+	write := func(char rune, start, end int) {
+		if fold {
+			if zeroWidthRunes[char] {
+				return
+			}
+			char = foldConfusable(char)
+		}
+
+		written, _ := builder.WriteRune(char)
+		for i := 0; i < written; i++ {
+			normalized.starts = append(normalized.starts, start)
+			normalized.ends = append(normalized.ends, end)
+		}
+	}
+
+	// writeUnchanged writes runes that keep their own original offsets
+	writeUnchanged := func(text string, base int) {
+		for offset, char := range text {
+			_, size := utf8.DecodeRuneInString(text[offset:])
+			write(char, base+offset, base+offset+size)
+		}
+	}
+
+	if !compose {
+		writeUnchanged(original, 0)
+		normalized.text = builder.String()
+		return normalized
+	}
+
+	// A segment that composition changed maps as a whole onto the original
+	// characters it came from, such as a base letter and its combining marks
+	var iter norm.Iter
+	iter.InitString(norm.NFC, original)
+	for start := 0; !iter.Done(); {
+		segment := string(iter.Next())
+		end := iter.Pos()
+		if segment == original[start:end] {
+			writeUnchanged(segment, start)
+		} else {
+			for _, char := range segment {
+				write(char, start, end)
+			}
+		}
+		start = end
+	}
+
+	normalized.text = builder.String()
+	return normalized
+}
+
+// foldConfusable maps a confusable or fullwidth rune to ASCII
+func foldConfusable(char rune) rune {
+	if folded, exists := confusableRunes[char]; exists {
+		return folded
+	}
+	if char >= '\uff01' && char <= '\uff5e' {
+		return char - 0xFEE0 // Fullwidth ASCII variants
+	}
+	return char
+}
+
+// span maps a span of the normalized text onto the original text
+func (n *normalizedText) span(start, end int) (int, int) {
+	return n.starts[start], n.ends[end-1]
+}
@@ -0,0 +1,97 @@
+package redaction
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNormalizationDefeatsObfuscation(t *testing.T) {
+	engine := NewEngine()
+	normalize := map[string]interface{}{normalizeOption: true}
+
+	tests := []struct {
+		name     string
+		text     string
+		original string
+	}{
+		{"Zero-width space", "Mail jo\u200bhn@acme.com now", "jo\u200bhn@acme.com"},
+		{"Cyrillic homoglyphs", "Mail jоhn@аcme.com now", "jоhn@аcme.com"},
+		{"Fullwidth digits", "SSN １２３-45-6789 on file", "１２３-45-6789"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plain, err := engine.RedactText(context.Background(), &Request{Text: tt.text, Mode: ModeReplace})
+			if err != nil {
+				t.Fatalf("RedactText failed: %v", err)
+			}
+			for _, redaction := range plain.Redactions {
+				if redaction.Original == tt.original {
+					t.Fatalf("Expected the obfuscated value to evade plain detection, got %+v", redaction)
+				}
+			}
+
+			result, err := engine.RedactText(context.Background(), &Request{Text: tt.text, Mode: ModeReplace, Options: normalize})
+			if err != nil {
+				t.Fatalf("RedactText failed: %v", err)
+			}
+			if len(result.Redactions) != 1 {
+				t.Fatalf("Expected one redaction, got %+v", result.Redactions)
+			}
+
+			redaction := result.Redactions[0]
+			if redaction.Original != tt.original || tt.text[redaction.Start:redaction.End] != tt.original {
+				t.Errorf("Expected the span to cover %q, got %q (%d-%d)",
+					tt.original, redaction.Original, redaction.Start, redaction.End)
+			}
+
+			prefix := tt.text[:redaction.Start]
+			suffix := tt.text[redaction.End:]
+			if result.RedactedText != prefix+redaction.Replacement+suffix {
+				t.Errorf("Unexpected redacted text: %q", result.RedactedText)
+			}
+		})
+	}
+}
+
+func TestNFCNormalization(t *testing.T) {
+	engine := NewEngine()
+	decomposed := "jose\u0301@example.com"
+	text := "Contact " + decomposed + " today"
+
+	plain, err := engine.RedactText(context.Background(), &Request{Text: text, Mode: ModeReplace})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+	if len(plain.Redactions) != 0 {
+		t.Fatalf("Expected the decomposed email to evade plain detection, got %+v", plain.Redactions)
+	}
+
+	precomposed, err := engine.RedactText(context.Background(), &Request{Text: "Contact jos\u00e9@example.com today", Mode: ModeReplace})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+	if len(precomposed.Redactions) != 1 || precomposed.Redactions[0].Original != "jos\u00e9@example.com" {
+		t.Fatalf("Expected the precomposed email to be detected, got %+v", precomposed.Redactions)
+	}
+
+	result, err := engine.RedactText(context.Background(), &Request{
+		Text:    text,
+		Mode:    ModeReplace,
+		Options: map[string]interface{}{nfcOption: true},
+	})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+	if len(result.Redactions) != 1 {
+		t.Fatalf("Expected one redaction, got %+v", result.Redactions)
+	}
+
+	redaction := result.Redactions[0]
+	if redaction.Start != len("Contact ") || redaction.End != len("Contact ")+len(decomposed) || redaction.Original != decomposed {
+		t.Errorf("Expected the span to cover the decomposed bytes, got %q (%d-%d)", redaction.Original, redaction.Start, redaction.End)
+	}
+	if result.RedactedText != "Contact [EMAIL_REDACTED] today" {
+		t.Errorf("Unexpected redacted text: %q", result.RedactedText)
+	}
+}
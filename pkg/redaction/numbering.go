@@ -0,0 +1,95 @@
+package redaction
+
+import "strconv"
+
+// numberPlaceholdersOption numbers the placeholders of replace mode so that
+// reviewers can refer to each redaction: "position" numbers the matches of
+// each placeholder in document order ([EMAIL_REDACTED_1], [EMAIL_REDACTED_2]),
+// "value" gives every occurrence of the same value the same number. true is
+// taken as "position".
+const numberPlaceholdersOption = "number_placeholders"
+
+// Placeholder numbering schemes accepted by numberPlaceholdersOption
+const (
+	numberByPosition = "position"
+	numberByValue    = "value"
+)
+
+// numberPlaceholders appends an index to the replacement of each redaction,
+// counting separately for each placeholder. redactions must be in document
+// order. Only types in plain replace mode are numbered; strategies, replacer
+// funcs and the other modes produce their own replacements.
+func numberPlaceholders(request *Request, redactions []Redaction) {
+	newPlaceholderNumbers().assign(request, redactions)
+}
+
+// placeholderNumbers holds the numbers handed out so far, so a document
+// redacted in pieces is numbered as if it were redacted whole
+type placeholderNumbers struct {
+	counts  map[string]int
+	numbers map[placeholderValue]int
+}
+
+// placeholderValue identifies a value under a placeholder for numbering by value
+type placeholderValue struct{ placeholder, value string }
+
+func newPlaceholderNumbers() *placeholderNumbers {
+	return &placeholderNumbers{
+		counts:  make(map[string]int),
+		numbers: make(map[placeholderValue]int),
+	}
+}
+
+// assign numbers redactions as numberPlaceholders does, continuing from the
+// redactions numbered before
+func (n *placeholderNumbers) assign(request *Request, redactions []Redaction) {
+	scheme := numberingScheme(request)
+	if scheme == "" {
+		return
+	}
+	if request.Strategy != "" || request.ReplacerFunc != nil {
+		return
+	}
+
+	for i := range redactions {
+		placeholder := redactions[i].Replacement
+		if placeholder == "" || request.modeFor(redactions[i].Type) != ModeReplace {
+			continue
+		}
+
+		key := placeholderValue{placeholder, redactions[i].Original}
+		number, seen := n.numbers[key]
+		if !seen || scheme == numberByPosition {
+			n.counts[placeholder]++
+			number = n.counts[placeholder]
+			n.numbers[key] = number
+		}
+		redactions[i].Replacement = numberedPlaceholder(placeholder, number)
+	}
+}
+
+// numberingScheme returns the requested numbering scheme, or "" when
+// placeholders are not numbered
+func numberingScheme(request *Request) string {
+	switch scheme := request.Options[numberPlaceholdersOption].(type) {
+	case bool:
+		if scheme {
+			return numberByPosition
+		}
+	case string:
+		if scheme == numberByPosition || scheme == numberByValue {
+			return scheme
+		}
+	}
+	return ""
+}
+
+// numberedPlaceholder inserts number before a placeholder's closing bracket,
+// or appends it when the placeholder has none
+func numberedPlaceholder(placeholder string, number int) string {
+	suffix := "_" + strconv.Itoa(number)
+	if last := len(placeholder) - 1; placeholder[last] == ']' {
+		return placeholder[:last] + suffix + "]"
+	}
+	return placeholder + suffix
+}
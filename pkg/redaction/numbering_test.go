@@ -0,0 +1,56 @@
+package redaction
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNumberPlaceholders(t *testing.T) {
+	engine := NewEngine()
+	text := "From a@example.com to b@example.com, cc a@example.com; SSN 123-45-6789"
+
+	redact := func(t *testing.T, request *Request) string {
+		t.Helper()
+		request.Text = text
+		result, err := engine.RedactText(context.Background(), request)
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		return result.RedactedText
+	}
+
+	t.Run("Numbered in document order", func(t *testing.T) {
+		got := redact(t, &Request{Options: map[string]interface{}{"number_placeholders": "position"}})
+		expected := "From [EMAIL_REDACTED_1] to [EMAIL_REDACTED_2], cc [EMAIL_REDACTED_3]; SSN [SSN_REDACTED_1]"
+		if got != expected {
+			t.Errorf("Expected %q, got %q", expected, got)
+		}
+	})
+
+	t.Run("True numbers by position", func(t *testing.T) {
+		got := redact(t, &Request{Options: map[string]interface{}{"number_placeholders": true}})
+		expected := "From [EMAIL_REDACTED_1] to [EMAIL_REDACTED_2], cc [EMAIL_REDACTED_3]; SSN [SSN_REDACTED_1]"
+		if got != expected {
+			t.Errorf("Expected %q, got %q", expected, got)
+		}
+	})
+
+	t.Run("Stable per value", func(t *testing.T) {
+		got := redact(t, &Request{Options: map[string]interface{}{"number_placeholders": "value"}})
+		expected := "From [EMAIL_REDACTED_1] to [EMAIL_REDACTED_2], cc [EMAIL_REDACTED_1]; SSN [SSN_REDACTED_1]"
+		if got != expected {
+			t.Errorf("Expected %q, got %q", expected, got)
+		}
+	})
+
+	t.Run("Other modes are not numbered", func(t *testing.T) {
+		got := redact(t, &Request{Mode: ModeRemove, Options: map[string]interface{}{"number_placeholders": "position"}})
+		if got != "From  to , cc ; SSN " {
+			t.Errorf("Unexpected output: %q", got)
+		}
+	})
+
+	if got := numberedPlaceholder("<email>", 2); got != "<email>_2" {
+		t.Errorf("Expected an index appended to an unbracketed placeholder, got %q", got)
+	}
+}
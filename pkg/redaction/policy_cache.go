@@ -0,0 +1,100 @@
+package redaction
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"regexp"
+)
+
+// maxCachedPolicies bounds the compiled rule sets kept by the policy cache;
+// the cache is emptied when it fills
+const maxCachedPolicies = 128
+
+// compiledPolicyRules holds the compiled patterns of a rule set, indexed like
+// the rules and their Patterns. Disabled rules have no entries.
+type compiledPolicyRules struct {
+	patterns [][]compiledPolicyPattern
+}
+
+// compiledPolicyPattern is one compiled rule pattern, or the error compiling
+// it, which is reported only if the rule is applied
+type compiledPolicyPattern struct {
+	regexp *regexp.Regexp
+	err    error
+}
+
+// compilePolicyRules returns the compiled patterns of rules, reusing those of
+// an identical rule set compiled earlier. A changed rule set hashes to a new
+// key, so edits to a policy never see stale patterns.
+func (re *Engine) compilePolicyRules(rules []PolicyRule) *compiledPolicyRules {
+	key := policyRulesKey(rules)
+
+	re.mutex.Lock()
+	compiled, exists := re.policyCache[key]
+	if exists {
+		re.policyCacheHits++
+	} else {
+		re.policyCacheMisses++
+	}
+	re.mutex.Unlock()
+
+	if exists {
+		return compiled
+	}
+
+	compiled = &compiledPolicyRules{patterns: make([][]compiledPolicyPattern, len(rules))}
+	for i, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+		compiled.patterns[i] = make([]compiledPolicyPattern, len(rule.Patterns))
+		for j, pattern := range rule.Patterns {
+			expr, err := compileUserPattern(pattern)
+			compiled.patterns[i][j] = compiledPolicyPattern{regexp: expr, err: err}
+		}
+	}
+
+	re.mutex.Lock()
+	if len(re.policyCache) >= maxCachedPolicies {
+		re.policyCache = make(map[string]*compiledPolicyRules)
+	}
+	re.policyCache[key] = compiled
+	re.mutex.Unlock()
+
+	return compiled
+}
+
+// ClearPolicyCache drops every cached compiled rule set
+func (re *Engine) ClearPolicyCache() {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.policyCache = make(map[string]*compiledPolicyRules)
+}
+
+// policyRulesKey hashes the parts of a rule set that compilation depends on:
+// the order of the rules, whether each is enabled, and its patterns
+func policyRulesKey(rules []PolicyRule) string {
+	hash := sha256.New()
+	var length [8]byte
+	write := func(value string) {
+		binary.BigEndian.PutUint64(length[:], uint64(len(value)))
+		hash.Write(length[:])
+		hash.Write([]byte(value))
+	}
+
+	for _, rule := range rules {
+		if !rule.Enabled {
+			write("-")
+			continue
+		}
+		write("+")
+		binary.BigEndian.PutUint64(length[:], uint64(len(rule.Patterns)))
+		hash.Write(length[:])
+		for _, pattern := range rule.Patterns {
+			write(pattern)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
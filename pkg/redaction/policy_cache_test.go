@@ -0,0 +1,96 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func policyCacheTestRequest() *PolicyRequest {
+	return &PolicyRequest{
+		Request: &Request{Text: "Ticket PROJ-4821 for ACCT-99812", Mode: ModeReplace},
+		PolicyRules: []PolicyRule{
+			{Name: "PROJECT", Patterns: []string{`PROJ-\d{4}`}, Mode: ModeReplace, Enabled: true},
+			{Name: "ACCOUNT", Patterns: []string{`ACCT-\d{5}`, `ACC-\d{5}`}, Mode: ModeReplace, Enabled: true},
+		},
+	}
+}
+
+func TestPolicyCache(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+	request := policyCacheTestRequest()
+
+	cacheStats := func() (int, int) {
+		stats := engine.GetRedactionStats()
+		return stats["policy_cache_hits"].(int), stats["policy_cache_misses"].(int)
+	}
+
+	for i := 0; i < 3; i++ {
+		result, err := engine.ApplyPolicyRules(ctx, request)
+		if err != nil {
+			t.Fatalf("ApplyPolicyRules failed: %v", err)
+		}
+		if len(result.Redactions) != 2 {
+			t.Fatalf("Expected both rules to match, got %+v", result.Redactions)
+		}
+	}
+	if hits, misses := cacheStats(); hits != 2 || misses != 1 {
+		t.Errorf("Expected 2 hits and 1 miss, got %d and %d", hits, misses)
+	}
+
+	// A changed pattern is a different rule set and is compiled afresh
+	request.PolicyRules[0].Patterns = []string{`PROJ-\d{3}`}
+	result, err := engine.ApplyPolicyRules(ctx, request)
+	if err != nil {
+		t.Fatalf("ApplyPolicyRules failed: %v", err)
+	}
+	if _, misses := cacheStats(); misses != 2 {
+		t.Errorf("Expected the changed policy to miss the cache, got %d misses", misses)
+	}
+	if result.Redactions[0].Original != "PROJ-482" {
+		t.Errorf("Expected the changed pattern to apply, got %+v", result.Redactions)
+	}
+
+	engine.ClearPolicyCache()
+	if _, err := engine.ApplyPolicyRules(ctx, request); err != nil {
+		t.Fatalf("ApplyPolicyRules failed: %v", err)
+	}
+	if _, misses := cacheStats(); misses != 3 {
+		t.Errorf("Expected a miss after clearing the cache, got %d misses", misses)
+	}
+
+	// Invalid patterns are cached too and still fail every application
+	request.PolicyRules[1].Patterns = []string{`ACCT-(\d{5}`}
+	for i := 0; i < 2; i++ {
+		if _, err := engine.ApplyPolicyRules(ctx, request); !errors.Is(err, ErrInvalidPattern) {
+			t.Errorf("Expected ErrInvalidPattern, got %v", err)
+		}
+	}
+}
+
+func BenchmarkApplyPolicyRulesCached(b *testing.B) {
+	benchmarkApplyPolicyRules(b, true)
+}
+
+func BenchmarkApplyPolicyRulesUncached(b *testing.B) {
+	benchmarkApplyPolicyRules(b, false)
+}
+
+// benchmarkApplyPolicyRules reports the rule set compilations per operation,
+// clearing the cache before each one when cached is false
+func benchmarkApplyPolicyRules(b *testing.B, cached bool) {
+	engine := NewEngine()
+	request := policyCacheTestRequest()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if !cached {
+			engine.ClearPolicyCache()
+		}
+		_, _ = engine.ApplyPolicyRules(context.Background(), request)
+	}
+
+	misses := engine.GetRedactionStats()["policy_cache_misses"].(int)
+	b.ReportMetric(float64(misses)/float64(b.N), "compiles/op")
+}
@@ -0,0 +1,46 @@
+package redaction
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// PolicyValidationResult summarizes the validation of a set of policy rules
+type PolicyValidationResult struct {
+	Valid        bool              `json:"valid"`
+	Errors       []ValidationError `json:"errors,omitempty"`
+	CountsByCode map[string]int    `json:"counts_by_code,omitempty"` // Number of errors with each code
+}
+
+// ValidatePolicyResult validates policy rules like ValidatePolicy and
+// summarizes the errors found
+func (re *Engine) ValidatePolicyResult(ctx context.Context, rules []PolicyRule) *PolicyValidationResult {
+	return newPolicyValidationResult(re.ValidatePolicy(ctx, rules))
+}
+
+// newPolicyValidationResult wraps validation errors with their summary
+func newPolicyValidationResult(errs []ValidationError) *PolicyValidationResult {
+	result := &PolicyValidationResult{Valid: len(errs) == 0, Errors: errs}
+	if len(errs) > 0 {
+		result.CountsByCode = make(map[string]int)
+		for _, err := range errs {
+			result.CountsByCode[err.Code]++
+		}
+	}
+	return result
+}
+
+// Error describes every validation error in one line, or returns "" if the
+// rules are valid
+func (r *PolicyValidationResult) Error() string {
+	if r.Valid {
+		return ""
+	}
+
+	messages := make([]string, len(r.Errors))
+	for i, err := range r.Errors {
+		messages[i] = fmt.Sprintf("rule %q: %s (%s)", err.Rule, err.Message, err.Code)
+	}
+	return fmt.Sprintf("policy has %d validation errors: %s", len(r.Errors), strings.Join(messages, "; "))
+}
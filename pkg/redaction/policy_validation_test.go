@@ -0,0 +1,59 @@
+package redaction
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestValidatePolicyResult(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+
+	valid := engine.ValidatePolicyResult(ctx, []PolicyRule{
+		{Name: "PROJECT", Patterns: []string{`PROJ-\d{4}`}, Mode: ModeReplace, Enabled: true},
+	})
+	if !valid.Valid || len(valid.Errors) != 0 || len(valid.CountsByCode) != 0 || valid.Error() != "" {
+		t.Errorf("Expected a valid policy, got %+v", valid)
+	}
+
+	rules := []PolicyRule{
+		{Name: "", Patterns: []string{`ok`}, Mode: ModeReplace},
+		{Name: "BROKEN", Patterns: []string{`[unclosed`, `(also`}, Mode: "scramble", Priority: -1},
+		{Name: "EMPTY", Mode: ModeReplace},
+	}
+	result := engine.ValidatePolicyResult(ctx, rules)
+	errs := engine.ValidatePolicy(ctx, rules)
+
+	if result.Valid {
+		t.Fatal("Expected an invalid policy")
+	}
+	if len(result.Errors) != len(errs) {
+		t.Fatalf("Expected the %d underlying errors, got %d", len(errs), len(result.Errors))
+	}
+
+	expected := make(map[string]int)
+	for _, err := range errs {
+		expected[err.Code]++
+	}
+	total := 0
+	for code, count := range result.CountsByCode {
+		if expected[code] != count {
+			t.Errorf("Expected %d %s errors, got %d", expected[code], code, count)
+		}
+		total += count
+	}
+	if len(result.CountsByCode) != len(expected) || total != len(errs) {
+		t.Errorf("Expected counts %v, got %v", expected, result.CountsByCode)
+	}
+	if result.CountsByCode["INVALID_PATTERN"] != 2 {
+		t.Errorf("Expected 2 INVALID_PATTERN errors, got %v", result.CountsByCode)
+	}
+
+	message := result.Error()
+	for _, want := range []string{"6 validation errors", `rule "BROKEN"`, "INVALID_MODE", "NO_PATTERNS"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("Expected %q in %q", want, message)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package redaction
+
+import (
+	"context"
+	"strings"
+)
+
+// skipRegionsOption lists delimiter pairs, such as {"```", "```"}, whose
+// enclosed text, delimiters included, is excluded from detection. An opening
+// delimiter without a closing one starts no region, so a stray fence never
+// hides the rest of a document.
+const skipRegionsOption = "skip_regions"
+
+// skipDelimiters reads the skip_regions option, accepting [][2]string,
+// [][]string and the []interface{} produced by JSON decoding. Malformed
+// pairs are ignored.
+func skipDelimiters(options map[string]interface{}) [][2]string {
+	var pairs [][2]string
+	add := func(opening, closing string) {
+		if opening != "" && closing != "" {
+			pairs = append(pairs, [2]string{opening, closing})
+		}
+	}
+
+	switch value := options[skipRegionsOption].(type) {
+	case [][2]string:
+		for _, pair := range value {
+			add(pair[0], pair[1])
+		}
+	case [][]string:
+		for _, pair := range value {
+			if len(pair) == 2 {
+				add(pair[0], pair[1])
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			pair, ok := item.([]interface{})
+			if !ok || len(pair) != 2 {
+				continue
+			}
+			opening, _ := pair[0].(string)
+			closing, _ := pair[1].(string)
+			add(opening, closing)
+		}
+	}
+	return pairs
+}
+
+// skipRegions returns the spans of text enclosed by the delimiter pairs
+func skipRegions(text string, pairs [][2]string) [][]int {
+	var regions [][]int
+	for _, pair := range pairs {
+		opening, closing := pair[0], pair[1]
+		for offset := 0; offset < len(text); {
+			start := strings.Index(text[offset:], opening)
+			if start < 0 {
+				break
+			}
+			start += offset
+
+			end := strings.Index(text[start+len(opening):], closing)
+			if end < 0 {
+				break
+			}
+			end += start + len(opening) + len(closing)
+
+			regions = append(regions, []int{start, end})
+			offset = end
+		}
+	}
+	return regions
+}
+
+// collectCandidates finds all pattern and detector matches in src, before
+// overlaps are resolved. Skipped regions are blanked out before detection,
+// so no match is found inside one and none runs across its delimiters;
+// spans and originals still refer to src.
+func (re *Engine) collectCandidates(ctx context.Context, src matchSource, request *Request) ([]Redaction, []error) {
+	pairs := skipDelimiters(request.Options)
+	if len(pairs) == 0 {
+		return re.scanCandidates(ctx, src, request)
+	}
+
+	text := src.slice(0, src.length())
+	regions := skipRegions(text, pairs)
+	if len(regions) == 0 {
+		return re.scanCandidates(ctx, src, request)
+	}
+
+	found, errs := re.scanCandidates(ctx, stringSource(blankRegions(text, regions)), request)
+	return re.clipToRegions(src, found, regions), errs
+}
+
+// blankRegions returns text with every byte of regions replaced by a space,
+// keeping the offsets of the text around them
+func blankRegions(text string, regions [][]int) string {
+	blanked := []byte(text)
+	for _, region := range regions {
+		for i := region[0]; i < region[1]; i++ {
+			blanked[i] = ' '
+		}
+	}
+	return string(blanked)
+}
+
+// clipToRegions takes the original text of each redaction from src. A
+// redaction that still reaches into a region, as a pattern spanning
+// whitespace can, is cut back to its part before the region, and one that
+// starts inside a region is dropped.
+func (re *Engine) clipToRegions(src matchSource, redactions []Redaction, regions [][]int) []Redaction {
+	kept := redactions[:0]
+	for _, redaction := range redactions {
+		end := redaction.End
+		inside := false
+		for _, region := range regions {
+			if redaction.Start >= region[0] && redaction.Start < region[1] {
+				inside = true
+				break
+			}
+			if redaction.Start < region[0] && end > region[0] {
+				end = region[0]
+			}
+		}
+		if inside {
+			continue
+		}
+
+		redaction.Original = src.slice(redaction.Start, end)
+		if end != redaction.End {
+			redaction.End = end
+			redaction.Replacement = re.generateReplacement(redaction.Type, redaction.Original)
+		}
+		redaction.Context = re.extractSourceContext(src, redaction.Start, end)
+		kept = append(kept, redaction)
+	}
+	return kept
+}
@@ -0,0 +1,92 @@
+package redaction
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestSkipRegions(t *testing.T) {
+	engine := NewEngine()
+	text := "Contact ops@example.com.\n```\nexample: user@example.com\n```\nOr jane@example.com"
+
+	result, err := engine.RedactText(context.Background(), &Request{
+		Text:    text,
+		Options: map[string]interface{}{"skip_regions": [][2]string{{"```", "```"}}},
+	})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+
+	expected := "Contact [EMAIL_REDACTED].\n```\nexample: user@example.com\n```\nOr [EMAIL_REDACTED]"
+	if result.RedactedText != expected {
+		t.Errorf("Expected %q, got %q", expected, result.RedactedText)
+	}
+	for _, redaction := range result.Redactions {
+		if text[redaction.Start:redaction.End] != redaction.Original {
+			t.Errorf("Offsets %d-%d do not cover %q", redaction.Start, redaction.End, redaction.Original)
+		}
+	}
+
+	t.Run("Options decoded from JSON", func(t *testing.T) {
+		var options map[string]interface{}
+		if err := json.Unmarshal([]byte(`{"skip_regions": [["<q>", "</q>"]]}`), &options); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		result, err := engine.RedactText(context.Background(), &Request{
+			Text:    "<q>a@example.com</q> b@example.com",
+			Options: options,
+		})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		if result.RedactedText != "<q>a@example.com</q> [EMAIL_REDACTED]" {
+			t.Errorf("Unexpected output: %q", result.RedactedText)
+		}
+	})
+
+	t.Run("Unclosed region is not skipped", func(t *testing.T) {
+		result, err := engine.RedactText(context.Background(), &Request{
+			Text:    "``` a@example.com",
+			Options: map[string]interface{}{"skip_regions": [][]string{{"```", "```"}}},
+		})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		if result.RedactedText != "``` [EMAIL_REDACTED]" {
+			t.Errorf("Unexpected output: %q", result.RedactedText)
+		}
+	})
+
+	t.Run("Match partly inside a region", func(t *testing.T) {
+		text := "Mail jane@example.comBEGIN user@example.com END"
+		result, err := engine.RedactText(context.Background(), &Request{
+			Text:    text,
+			Options: map[string]interface{}{"skip_regions": [][2]string{{"BEGIN", "END"}}},
+		})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		if result.RedactedText != "Mail [EMAIL_REDACTED]BEGIN user@example.com END" {
+			t.Errorf("Expected the part outside the region redacted, got %q", result.RedactedText)
+		}
+		if len(result.Redactions) != 1 || result.Redactions[0].Original != "jane@example.com" {
+			t.Errorf("Expected one redaction of the email outside the region, got %+v", result.Redactions)
+		}
+	})
+
+	t.Run("Value across a region is not joined", func(t *testing.T) {
+		result, err := engine.RedactText(context.Background(), &Request{
+			Text:    "SSN 123-45-<q>note</q>6789 filed",
+			Options: map[string]interface{}{"skip_regions": [][2]string{{"<q>", "</q>"}}},
+		})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		for _, redaction := range result.Redactions {
+			if redaction.End > len("SSN 123-45-") && redaction.Start < len("SSN 123-45-<q>note</q>") {
+				t.Errorf("Expected no match to reach into the region, got %+v", redaction)
+			}
+		}
+	})
+}
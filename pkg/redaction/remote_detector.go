@@ -0,0 +1,112 @@
+package redaction
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Entity is a named entity found by an external recognizer. Start and End are
+// byte offsets into the recognized text; Score is the recognizer's confidence
+// in the range (0, 1].
+type Entity struct {
+	Label string  `json:"label"`
+	Start int     `json:"start"`
+	End   int     `json:"end"`
+	Text  string  `json:"text,omitempty"`
+	Score float64 `json:"score,omitempty"`
+}
+
+// EntityRecognizer finds named entities in text, typically by calling a
+// remote NER service
+type EntityRecognizer interface {
+	Recognize(ctx context.Context, text string) ([]Entity, error)
+}
+
+// defaultEntityTypes maps common NER labels to redaction types
+var defaultEntityTypes = map[string]Type{
+	"PERSON":       TypeName,
+	"PER":          TypeName,
+	"NAME":         TypeName,
+	"ORG":          TypeOrganization,
+	"ORGANIZATION": TypeOrganization,
+	"LOC":          TypeLocation,
+	"LOCATION":     TypeLocation,
+	"GPE":          TypeLocation,
+	"ADDRESS":      TypeAddress,
+	"EMAIL":        TypeEmail,
+	"PHONE":        TypePhone,
+}
+
+// RemoteDetector is a Detector backed by an EntityRecognizer. Recognizer
+// failures, such as network errors, fail only this detector: the engine
+// reports them in Result.Errors and still applies the pattern matches.
+type RemoteDetector struct {
+	name       string
+	recognizer EntityRecognizer
+
+	mutex  sync.RWMutex
+	labels map[string]Type
+}
+
+// NewRemoteDetector creates a detector that redacts the entities found by recognizer
+func NewRemoteDetector(name string, recognizer EntityRecognizer) *RemoteDetector {
+	labels := make(map[string]Type, len(defaultEntityTypes))
+	for label, redactionType := range defaultEntityTypes {
+		labels[label] = redactionType
+	}
+
+	return &RemoteDetector{
+		name:       name,
+		recognizer: recognizer,
+		labels:     labels,
+	}
+}
+
+// SetLabelType maps a recognizer label (case-insensitive) to a redaction type
+func (d *RemoteDetector) SetLabelType(label string, redactionType Type) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.labels[strings.ToUpper(label)] = redactionType
+}
+
+// Name implements Detector
+func (d *RemoteDetector) Name() string {
+	return d.name
+}
+
+// Detect implements Detector by mapping recognized entities to redactions.
+// Labels without a mapping become a type named after the lower-cased label.
+func (d *RemoteDetector) Detect(ctx context.Context, text string) ([]Redaction, error) {
+	entities, err := d.recognizer.Recognize(ctx, text)
+	if err != nil {
+		return nil, fmt.Errorf("entity recognition failed: %w", err)
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	redactions := make([]Redaction, 0, len(entities))
+	for _, entity := range entities {
+		redactionType, exists := d.labels[strings.ToUpper(entity.Label)]
+		if !exists {
+			redactionType = Type(strings.ToLower(entity.Label))
+		}
+
+		confidence := entity.Score
+		if confidence > 1 {
+			confidence = 1
+		}
+
+		redactions = append(redactions, Redaction{
+			Type:       redactionType,
+			Start:      entity.Start,
+			End:        entity.End,
+			Confidence: confidence,
+		})
+	}
+
+	return redactions, nil
+}
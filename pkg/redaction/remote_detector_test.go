@@ -0,0 +1,88 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeRecognizer returns fixed entities, or err when set
+type fakeRecognizer struct {
+	entities []Entity
+	err      error
+}
+
+func (r *fakeRecognizer) Recognize(context.Context, string) ([]Entity, error) {
+	return r.entities, r.err
+}
+
+// entityAt builds an entity covering the first occurrence of value in text
+func entityAt(text, value, label string, score float64) Entity {
+	start := strings.Index(text, value)
+	return Entity{Label: label, Start: start, End: start + len(value), Text: value, Score: score}
+}
+
+func TestRemoteDetector(t *testing.T) {
+	ctx := context.Background()
+	text := "Alice Smith from Acme Corp wrote from alice@acme.com"
+
+	t.Run("Entities become redactions", func(t *testing.T) {
+		recognizer := &fakeRecognizer{entities: []Entity{
+			entityAt(text, "Alice Smith", "PERSON", 0.92),
+			entityAt(text, "Acme Corp", "ORG", 0.81),
+		}}
+		engine := NewEngine()
+		engine.AddDetector(NewRemoteDetector("ner", recognizer))
+
+		result, err := engine.RedactText(ctx, &Request{Text: text, Mode: ModeReplace})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+
+		expected := "[NAME_REDACTED] from [ORGANIZATION_REDACTED] wrote from [EMAIL_REDACTED]"
+		if result.RedactedText != expected {
+			t.Errorf("Expected %q, got %q", expected, result.RedactedText)
+		}
+		if result.Redactions[0].Type != TypeName || result.Redactions[0].Confidence != 0.92 {
+			t.Errorf("Expected the person entity with its score, got %+v", result.Redactions[0])
+		}
+		if result.Redactions[1].Type != TypeOrganization || result.Redactions[1].Confidence != 0.81 {
+			t.Errorf("Expected the organization entity with its score, got %+v", result.Redactions[1])
+		}
+	})
+
+	t.Run("Entities take part in overlap resolution", func(t *testing.T) {
+		recognizer := &fakeRecognizer{entities: []Entity{
+			entityAt(text, "Alice Smith", "PERSON", 0.92),
+			entityAt(text, "acme.com", "ORG", 0.6),
+		}}
+		engine := NewEngine()
+		engine.AddDetector(NewRemoteDetector("ner", recognizer))
+
+		result, err := engine.RedactText(ctx, &Request{Text: text, Mode: ModeReplace})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		if len(result.Redactions) != 2 || result.Redactions[1].Type != TypeEmail {
+			t.Errorf("Expected the longer email match to win over the entity, got %+v", result.Redactions)
+		}
+	})
+
+	t.Run("Recognizer errors degrade gracefully", func(t *testing.T) {
+		engine := NewEngine()
+		engine.AddDetector(NewRemoteDetector("ner", &fakeRecognizer{err: errors.New("connection refused")}))
+		engine.AddDetector(&staticDetector{word: "Alice", kind: TypeName})
+
+		result, err := engine.RedactText(ctx, &Request{Text: text, Mode: ModeReplace})
+		if err != nil {
+			t.Fatalf("Expected a partial result, got %v", err)
+		}
+		if !strings.Contains(result.RedactedText, "[EMAIL_REDACTED]") {
+			t.Errorf("Expected pattern redactions to survive, got %s", result.RedactedText)
+		}
+		if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "connection refused") {
+			t.Errorf("Expected the recognizer failure in Result.Errors, got %v", result.Errors)
+		}
+	})
+}
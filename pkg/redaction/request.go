@@ -0,0 +1,113 @@
+package redaction
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks a request for problems that would otherwise surface
+// mid-redaction: an unknown mode or type mode, a negative TTL and custom
+// patterns that do not compile. Every problem found is reported in the
+// returned error, which wraps ErrInvalidPattern when a pattern failed. An
+// empty mode is valid and means replace. Types are checked against an
+// engine's patterns and detectors when the request is redacted.
+func (r *Request) Validate() error {
+	var errs []error
+
+	if r.Mode != "" && !isValidMode(r.Mode) {
+		errs = append(errs, fmt.Errorf("invalid redaction mode: %q (valid modes: %v)", r.Mode, validModes))
+	}
+
+	if r.TTL < 0 {
+		errs = append(errs, fmt.Errorf("negative TTL: %v", r.TTL))
+	}
+	for redactionType, ttl := range r.TypeTTL {
+		if ttl < 0 {
+			errs = append(errs, fmt.Errorf("negative TTL for type %s: %v", redactionType, ttl))
+		}
+	}
+
+	for _, pattern := range r.CustomPatterns {
+		if _, err := compileUserPattern(pattern.Pattern); err != nil {
+			errs = append(errs, fmt.Errorf("custom pattern %s: %w", pattern.Name, err))
+		}
+	}
+
+	for redactionType, mode := range r.TypeModes {
+		if !isValidMode(mode) {
+			errs = append(errs, fmt.Errorf("invalid redaction mode for type %s: %q (valid modes: %v)", redactionType, mode, validModes))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkTypes rejects request types the engine can never report: types that
+// are not built in, not registered with AddCustomPattern and not declared by a
+// TypedDetector. When a detector that does not declare its types is
+// registered, any type may be reported and none is rejected.
+func (re *Engine) checkTypes(request *Request) error {
+	re.mutex.RLock()
+	defer re.mutex.RUnlock()
+
+	declared := make(map[Type]bool)
+	for _, detector := range re.detectors {
+		typed, ok := detector.(TypedDetector)
+		if !ok {
+			return nil
+		}
+		for _, redactionType := range typed.Types() {
+			declared[redactionType] = true
+		}
+	}
+
+	known := func(redactionType Type) bool {
+		_, builtIn := replacementMap[redactionType]
+		_, registered := re.patterns[redactionType]
+		return builtIn || registered || declared[redactionType] || redactionType == TypeCustom
+	}
+
+	var errs []error
+	for _, redactionType := range request.Types {
+		if !known(redactionType) {
+			errs = append(errs, fmt.Errorf("unknown redaction type: %q", redactionType))
+		}
+	}
+	for redactionType := range request.TypePriorities {
+		if !known(redactionType) {
+			errs = append(errs, fmt.Errorf("unknown redaction type in priorities: %q", redactionType))
+		}
+	}
+	for redactionType := range request.TypeModes {
+		if !known(redactionType) {
+			errs = append(errs, fmt.Errorf("unknown redaction type in modes: %q", redactionType))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// modeFor returns the mode applied to matches of a type: its TypeModes entry
+// if any, otherwise Mode, with an empty mode meaning replace
+func (r *Request) modeFor(redactionType Type) Mode {
+	if mode, exists := r.TypeModes[redactionType]; exists {
+		return mode
+	}
+	if r.Mode == "" {
+		return ModeReplace
+	}
+	return r.Mode
+}
+
+// CustomPattern converts a pattern from a PatternProvider into a request
+// custom pattern. Its Group carries over, so only that named capture group of
+// each match is redacted.
+func (p *Pattern) CustomPattern() CustomPattern {
+	return CustomPattern{
+		Name:        p.Name,
+		Pattern:     p.Regex,
+		Replacement: p.Replacement,
+		Confidence:  p.Confidence,
+		Description: p.Description,
+		Group:       p.Group,
+	}
+}
@@ -0,0 +1,168 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequestValidate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		request Request
+		wantErr string
+	}{
+		{"Valid request", Request{
+			Text:           "hello",
+			Mode:           ModeTokenize,
+			TTL:            time.Hour,
+			Types:          []Type{TypeEmail, TypeCustom},
+			CustomPatterns: []CustomPattern{{Name: "ticket", Pattern: `PROJ-\d+`}},
+		}, ""},
+		{"Empty mode", Request{Text: "hello"}, ""},
+		{"Invalid mode", Request{Mode: "shred"}, `invalid redaction mode: "shred"`},
+		{"Negative TTL", Request{TTL: -time.Second}, "negative TTL"},
+		{"Negative type TTL", Request{TypeTTL: map[Type]time.Duration{TypeSSN: -time.Minute}}, "negative TTL for type ssn"},
+		{"Invalid custom pattern", Request{CustomPatterns: []CustomPattern{{Name: "broken", Pattern: `(`}}}, "custom pattern broken"},
+		{"Invalid type mode", Request{TypeModes: map[Type]Mode{TypeSSN: "shred"}}, `invalid redaction mode for type ssn: "shred"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.request.Validate()
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected a valid request, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected an error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+
+	t.Run("Errors are aggregated", func(t *testing.T) {
+		request := Request{
+			Mode:           "shred",
+			TTL:            -time.Second,
+			CustomPatterns: []CustomPattern{{Name: "broken", Pattern: `[`}},
+			TypeModes:      map[Type]Mode{TypeSSN: "shred"},
+		}
+		err := request.Validate()
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		if lines := strings.Split(err.Error(), "\n"); len(lines) != 4 {
+			t.Errorf("Expected four problems, got %q", err)
+		}
+		if !errors.Is(err, ErrInvalidPattern) {
+			t.Errorf("Expected the error to wrap ErrInvalidPattern, got %v", err)
+		}
+	})
+
+	t.Run("RedactText validates up front", func(t *testing.T) {
+		_, err := NewEngine().RedactText(context.Background(), &Request{
+			Text:  "john@example.com",
+			Types: []Type{"shoe_size"},
+		})
+		if err == nil || !strings.Contains(err.Error(), "unknown redaction type") {
+			t.Errorf("Expected RedactText to reject the request, got %v", err)
+		}
+	})
+}
+
+func TestRequestTypesCheckedAgainstEngine(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Custom pattern type", func(t *testing.T) {
+		engine := NewEngine()
+		if err := engine.AddCustomPattern("employee_id", `E\d{5}`); err != nil {
+			t.Fatalf("AddCustomPattern failed: %v", err)
+		}
+
+		result, err := engine.RedactText(ctx, &Request{
+			Text:           "Employee E12345 badge",
+			Types:          []Type{"employee_id"},
+			TypePriorities: map[Type]int{"employee_id": 10},
+			TypeModes:      map[Type]Mode{"employee_id": ModeMask},
+		})
+		if err != nil {
+			t.Fatalf("Expected the registered type to be accepted, got %v", err)
+		}
+		if len(result.Redactions) != 1 || result.Redactions[0].Type != "employee_id" {
+			t.Errorf("Expected one employee_id redaction, got %+v", result.Redactions)
+		}
+	})
+
+	t.Run("Declared detector type", func(t *testing.T) {
+		engine := NewEngine()
+		engine.AddDetector(&typedStaticDetector{staticDetector{word: "Falcon", kind: "codename"}})
+
+		if _, err := engine.RedactText(ctx, &Request{Text: "Project Falcon", Types: []Type{"codename"}}); err != nil {
+			t.Errorf("Expected the declared type to be accepted, got %v", err)
+		}
+		_, err := engine.RedactText(ctx, &Request{Text: "Project Falcon", Types: []Type{"shoe_size"}})
+		if err == nil || !strings.Contains(err.Error(), `unknown redaction type: "shoe_size"`) {
+			t.Errorf("Expected an undeclared type to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("Undeclared detector types", func(t *testing.T) {
+		engine := NewEngine()
+		engine.AddDetector(&staticDetector{word: "Falcon", kind: "codename"})
+
+		result, err := engine.RedactText(ctx, &Request{Text: "Project Falcon", Types: []Type{"codename"}})
+		if err != nil {
+			t.Fatalf("Expected any type to be accepted, got %v", err)
+		}
+		if len(result.Redactions) != 1 || result.Redactions[0].Type != "codename" {
+			t.Errorf("Expected one codename redaction, got %+v", result.Redactions)
+		}
+	})
+
+	t.Run("Unknown types", func(t *testing.T) {
+		_, err := NewEngine().RedactText(ctx, &Request{
+			Text:           "hello",
+			Types:          []Type{"shoe_size"},
+			TypePriorities: map[Type]int{"hat_size": 1},
+			TypeModes:      map[Type]Mode{"glove_size": ModeMask},
+		})
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+		for _, want := range []string{
+			`unknown redaction type: "shoe_size"`,
+			`unknown redaction type in priorities: "hat_size"`,
+			`unknown redaction type in modes: "glove_size"`,
+		} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected the error to contain %q, got %v", want, err)
+			}
+		}
+	})
+}
+
+func TestPatternCustomPattern(t *testing.T) {
+	provided := &Pattern{
+		Name:  "employee",
+		Regex: `Employee ID: (?P<id>E\d{5})`,
+		Group: "id",
+	}
+
+	result, err := NewEngine().RedactText(context.Background(), &Request{
+		Text:           "Employee ID: E12345 joined",
+		CustomPatterns: []CustomPattern{provided.CustomPattern()},
+	})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+	if len(result.Redactions) != 1 || result.Redactions[0].Original != "E12345" {
+		t.Fatalf("Expected only the named group redacted, got %+v", result.Redactions)
+	}
+	if !strings.HasPrefix(result.RedactedText, "Employee ID: ") {
+		t.Errorf("Expected the rest of the match kept, got %q", result.RedactedText)
+	}
+}
@@ -0,0 +1,47 @@
+package redaction
+
+import (
+	"sort"
+	"unicode/utf8"
+)
+
+// setRuneOffsets fills in RuneStart and RuneEnd from the byte offsets of each
+// group of redactions over text, walking text once whatever the number of
+// spans. Each byte that starts a UTF-8 sequence counts as one rune.
+func setRuneOffsets[T string | []byte](text T, groups ...[]Redaction) {
+	type offset struct {
+		bytes int
+		runes *int
+	}
+
+	var offsets []offset
+	for _, redactions := range groups {
+		for i := range redactions {
+			offsets = append(offsets,
+				offset{redactions[i].Start, &redactions[i].RuneStart},
+				offset{redactions[i].End, &redactions[i].RuneEnd})
+		}
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i].bytes < offsets[j].bytes })
+
+	runes, position := 0, 0
+	for _, o := range offsets {
+		for ; position < o.bytes && position < len(text); position++ {
+			if utf8.RuneStart(text[position]) {
+				runes++
+			}
+		}
+		*o.runes = runes
+	}
+}
+
+// countRunes counts the runes of text the way setRuneOffsets does
+func countRunes(text string) int {
+	count := 0
+	for i := 0; i < len(text); i++ {
+		if utf8.RuneStart(text[i]) {
+			count++
+		}
+	}
+	return count
+}
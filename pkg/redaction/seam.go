@@ -0,0 +1,115 @@
+package redaction
+
+import "context"
+
+// seamRedactor redacts a document that arrives in pieces, for RedactStream
+// and RedactSession. The last stretch of text received, as long as the
+// longest possible match, is held back until more arrives, so a match
+// straddling two pieces is found whole; the text before it is final and is
+// redacted as RedactText would redact it, under one budget and one
+// placeholder numbering for the whole document.
+type seamRedactor struct {
+	engine      *Engine
+	request     *Request
+	maxMatchLen int
+
+	// pending is the text received but not yet emitted; offset and
+	// runeOffset locate its start in the document in bytes and runes
+	pending    string
+	offset     int
+	runeOffset int
+
+	budget    *redactionBudget
+	emitted   int
+	numbers   *placeholderNumbers
+	truncated bool
+}
+
+// newSeamRedactor returns a seamRedactor for a checked request
+func (re *Engine) newSeamRedactor(request *Request, maxMatchLen int) *seamRedactor {
+	return &seamRedactor{
+		engine:      re,
+		request:     request,
+		maxMatchLen: maxMatchLen,
+		budget:      newRedactionBudget(request),
+		numbers:     newPlaceholderNumbers(),
+	}
+}
+
+// feed adds text to the document and returns the text that became final,
+// its redacted form and its redactions at document offsets. When last is
+// set, all the text held back is final. Once the budget is spent the output
+// ends where the first redaction that did not fit starts, and nothing more
+// is returned.
+func (s *seamRedactor) feed(ctx context.Context, text string, last bool) (string, string, []Redaction, []error) {
+	if s.truncated {
+		return "", "", nil, nil
+	}
+
+	window := s.pending + text
+	src := stringSource(window)
+
+	// Everything before the seam can be finalised; at the end that is all of it
+	cut := len(window)
+	if !last {
+		cut = seamStart(window, s.maxMatchLen)
+	}
+
+	found, candidates, errs := s.engine.findRedactions(ctx, src, s.request, findOptions{})
+	if gap := mergeGap(s.request.Options); gap > 0 {
+		found = s.engine.mergeAdjacent(src, found, gap)
+	}
+
+	var final []Redaction
+	for _, redaction := range found {
+		if redaction.Start >= cut {
+			break
+		}
+		final = append(final, redaction)
+		cut = maxInt(cut, redaction.End)
+	}
+
+	for i := range final {
+		if !s.budget.allows(s.emitted + i) {
+			final, cut = s.truncate(final, i)
+			break
+		}
+		if err := s.engine.applyReplacement(ctx, s.request, &final[i]); err != nil {
+			errs = append(errs, err)
+		}
+		if !s.budget.spend(final[i]) {
+			final, cut = s.truncate(final, i)
+			break
+		}
+	}
+	s.numbers.assign(s.request, final)
+	s.engine.applyContextCapture(src, s.request, final, candidatesBefore(candidates, cut))
+	s.engine.recordMatches(final)
+	s.emitted += len(final)
+
+	output := applyRedactionsWithOptions(window[:cut], final, s.request)
+	setRuneOffsets(window, final)
+	for i := range final {
+		final[i].Start += s.offset
+		final[i].End += s.offset
+		final[i].RuneStart += s.runeOffset
+		final[i].RuneEnd += s.runeOffset
+	}
+
+	s.pending = window[cut:]
+	s.offset += cut
+	s.runeOffset += countRunes(window[:cut])
+	if s.truncated {
+		s.pending = ""
+	}
+
+	return window[:cut], output, final, errs
+}
+
+// truncate stops the document at final[i], the first redaction that did not
+// fit the budget, dropping the token minted for it
+func (s *seamRedactor) truncate(final []Redaction, i int) ([]Redaction, int) {
+	s.truncated = true
+	s.engine.discardTokens(final[i:])
+	return final[:i], final[i].Start
+}
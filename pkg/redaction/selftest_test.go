@@ -0,0 +1,39 @@
+package redaction
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestSelfTest(t *testing.T) {
+	t.Run("Default patterns pass", func(t *testing.T) {
+		engine := NewEngine()
+		if err := engine.SelfTest(); err != nil {
+			t.Fatalf("Expected self-test to pass, got %v", err)
+		}
+	})
+
+	t.Run("Tampered pattern fails", func(t *testing.T) {
+		engine := NewEngine()
+		engine.patterns[TypeEmail] = regexp.MustCompile(`$never^`)
+
+		err := engine.SelfTest()
+		if err == nil {
+			t.Fatal("Expected self-test to fail for a pattern that never matches")
+		}
+		if !strings.Contains(err.Error(), string(TypeEmail)) {
+			t.Errorf("Expected error to name the broken type, got %v", err)
+		}
+	})
+
+	t.Run("Custom patterns are compile-checked only", func(t *testing.T) {
+		engine := NewEngine()
+		if err := engine.AddCustomPattern("order_id", `ORD-\d{6}`); err != nil {
+			t.Fatalf("AddCustomPattern failed: %v", err)
+		}
+		if err := engine.SelfTest(); err != nil {
+			t.Errorf("Expected self-test to pass with a custom pattern, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package redaction
+
+import (
+	"context"
+	"time"
+)
+
+// RedactSession redacts a document that grows over several calls, such as a
+// chat transcript, without re-scanning what came before. The end of the text
+// appended so far is held back and scanned again with the next append, so a
+// match that straddles an append boundary is found whole and none of it is
+// returned unredacted. Flush returns the text still held back once the
+// document is complete. A RedactSession is not safe for concurrent use.
+type RedactSession struct {
+	engine   *Engine
+	request  *Request
+	redactor *seamRedactor
+}
+
+// NewSession starts an incremental redaction session. The template's types,
+// modes, options and budget apply to the document as a whole; its Text is
+// ignored and sessions are not reversible.
+func (re *Engine) NewSession(template *Request) (*RedactSession, error) {
+	request := segmentRequest(template)
+	request.Text = ""
+	if err := re.checkRequest(context.Background(), request, 0); err != nil {
+		return nil, err
+	}
+
+	return &RedactSession{
+		engine:   re,
+		request:  request,
+		redactor: re.newSeamRedactor(request, re.maxMatchLength(defaultMaxMatchLength)),
+	}, nil
+}
+
+// Append redacts text appended to the session's document. Text near the end
+// of the document is held back until a later Append or Flush, so the
+// result's OriginalText and RedactedText cover the text that became final,
+// which may start in an earlier append and stop short of this one. Its
+// redactions carry offsets into the whole document.
+func (s *RedactSession) Append(ctx context.Context, text string) (*Result, error) {
+	return s.redact(ctx, text, false)
+}
+
+// Flush redacts the text still held back, ending the document. Appending
+// after a flush starts a new stretch of the same document.
+func (s *RedactSession) Flush(ctx context.Context) (*Result, error) {
+	return s.redact(ctx, "", true)
+}
+
+// redact feeds text to the session's redactor and builds the result of the
+// text that became final
+func (s *RedactSession) redact(ctx context.Context, text string, last bool) (*Result, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	if err := s.engine.checkTextLength(s.request, len(text)); err != nil {
+		return nil, err
+	}
+
+	original, redacted, redactions, errs := s.redactor.feed(ctx, text, last)
+	if redactions == nil {
+		redactions = []Redaction{}
+	}
+
+	result := &Result{
+		OriginalText: original,
+		RedactedText: redacted,
+		Redactions:   redactions,
+		Truncated:    s.redactor.truncated,
+		Errors:       errorStrings(errs),
+		Timestamp:    time.Now(),
+	}
+	return result, s.engine.detectorFailure(errs)
+}
@@ -0,0 +1,127 @@
+package redaction
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// runSession appends chunks to a new session and flushes it, returning the
+// joined output and redactions
+func runSession(t *testing.T, engine *Engine, template *Request, chunks []string) (string, []Redaction) {
+	t.Helper()
+	ctx := context.Background()
+
+	session, err := engine.NewSession(template)
+	if err != nil {
+		t.Fatalf("NewSession failed: %v", err)
+	}
+
+	var output strings.Builder
+	var redactions []Redaction
+	collect := func(result *Result, err error) {
+		if err != nil {
+			t.Fatalf("Session failed: %v", err)
+		}
+		output.WriteString(result.RedactedText)
+		redactions = append(redactions, result.Redactions...)
+	}
+	for _, chunk := range chunks {
+		collect(session.Append(ctx, chunk))
+	}
+	collect(session.Flush(ctx))
+
+	return output.String(), redactions
+}
+
+func TestRedactSessionAppend(t *testing.T) {
+	engine := NewEngine()
+
+	chunks := []string{
+		"Hi, I'm jane@example.com. ",
+		"Call me on 555-123-4567 ",
+		"or mail jane@example.com again",
+	}
+	document := strings.Join(chunks, "")
+
+	output, redactions := runSession(t, engine, &Request{Mode: ModeReplace}, chunks)
+
+	expected := "Hi, I'm [EMAIL_REDACTED]. Call me on [PHONE_REDACTED] or mail [EMAIL_REDACTED] again"
+	if output != expected {
+		t.Errorf("Expected %q, got %q", expected, output)
+	}
+	if len(redactions) != 3 {
+		t.Fatalf("Expected three redactions, got %+v", redactions)
+	}
+	for _, redaction := range redactions {
+		if document[redaction.Start:redaction.End] != redaction.Original {
+			t.Errorf("Offsets %d-%d do not locate %q in the document", redaction.Start, redaction.End, redaction.Original)
+		}
+	}
+}
+
+func TestRedactSessionMatchAcrossAppends(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+
+	session, err := engine.NewSession(nil)
+	if err != nil {
+		t.Fatalf("NewSession failed: %v", err)
+	}
+
+	first, err := session.Append(ctx, "Mail jane@exa")
+	if err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if strings.Contains(first.RedactedText, "jane") {
+		t.Errorf("Expected the start of the email held back, got %q", first.RedactedText)
+	}
+
+	second, err := session.Append(ctx, "mple.com now")
+	if err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	flushed, err := session.Flush(ctx)
+	if err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	output := first.RedactedText + second.RedactedText + flushed.RedactedText
+	if output != "Mail [EMAIL_REDACTED] now" {
+		t.Errorf("Expected the straddling email redacted whole, got %q", output)
+	}
+
+	redactions := append(append(first.Redactions, second.Redactions...), flushed.Redactions...)
+	if len(redactions) != 1 || redactions[0].Start != 5 || redactions[0].Original != "jane@example.com" {
+		t.Errorf("Expected the straddling email reported once, got %+v", redactions)
+	}
+}
+
+func TestRedactSessionUsesRequestTemplate(t *testing.T) {
+	engine := NewEngine()
+	text := strings.Repeat("Email test@example.com, SSN 123-45-6789. ", 12)
+	chunks := []string{text[:100], text[100:250], text[250:]}
+
+	template := &Request{
+		Types:   []Type{TypeEmail, TypeSSN},
+		Options: map[string]interface{}{"max_redactions": 5, "number_placeholders": true},
+	}
+	request := *template
+	request.Text = text
+	expected, err := engine.RedactText(context.Background(), &request)
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+
+	output, redactions := runSession(t, engine, template, chunks)
+	if output != expected.RedactedText {
+		t.Errorf("Expected %q, got %q", expected.RedactedText, output)
+	}
+	if len(redactions) != len(expected.Redactions) {
+		t.Errorf("Expected %d redactions, got %d", len(expected.Redactions), len(redactions))
+	}
+
+	if _, err := engine.NewSession(&Request{Types: []Type{"shoe_size"}}); err == nil {
+		t.Error("Expected NewSession to validate the template")
+	}
+}
@@ -0,0 +1,85 @@
+package redaction
+
+import (
+	"regexp"
+	"strings"
+)
+
+// shortenerMetadataKey is the Redaction.Metadata key set to true on links
+// through a link shortener, which can hide tracking tokens
+const shortenerMetadataKey = "shortener"
+
+// redactShortenedLinksOption, when true, keeps shortened links redacted where
+// links are otherwise left alone, such as in HTML attribute values
+const redactShortenedLinksOption = "redact_shortened_links"
+
+// defaultShortenerHosts are the link shorteners recognised by default
+var defaultShortenerHosts = []string{
+	"bit.ly", "t.co", "tinyurl.com", "goo.gl", "ow.ly", "is.gd", "buff.ly", "rebrand.ly", "cutt.ly", "lnkd.in",
+}
+
+// shortenerPattern matches links through any of hosts, with or without a
+// scheme, or returns nil when there are no hosts
+func shortenerPattern(hosts []string) *regexp.Regexp {
+	quoted := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		if host = strings.TrimSpace(host); host != "" {
+			quoted = append(quoted, regexp.QuoteMeta(strings.ToLower(host)))
+		}
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+
+	urlChar := `[^\s<>"{}|\\^` + "`" + `\[\]]`
+	return regexp.MustCompile(`(?i)\b(?:https?://)?(?:www\.)?(?:` + strings.Join(quoted, "|") + `)/` + urlChar + `+`)
+}
+
+// SetShortenerHosts replaces the link shortener hosts, such as "bit.ly",
+// whose links are detected as TypeLink with Metadata["shortener"] set.
+// Shortened links are detected without a scheme. An empty list disables
+// shortener detection.
+func (re *Engine) SetShortenerHosts(hosts []string) {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.shorteners = shortenerPattern(hosts)
+}
+
+// collectShortenedLinks adds the shortened links in src to redactions as
+// TypeLink matches flagged as shorteners, replacing the plain link matches
+// they cover
+func (re *Engine) collectShortenedLinks(src matchSource, redactions []Redaction) []Redaction {
+	re.mutex.RLock()
+	pattern := re.shorteners
+	re.mutex.RUnlock()
+
+	if pattern == nil {
+		return redactions
+	}
+	matches := src.findAll(pattern)
+	if len(matches) == 0 {
+		return redactions
+	}
+
+	kept := redactions[:0]
+	for _, redaction := range redactions {
+		if redaction.Type == TypeLink && withinAny(redaction.Start, redaction.End, matches) {
+			continue
+		}
+		kept = append(kept, redaction)
+	}
+
+	for _, match := range matches {
+		link := re.patternRedaction(src, TypeLink, match[0], match[1], defaultConfidence)
+		link.Metadata = map[string]interface{}{shortenerMetadataKey: true}
+		kept = append(kept, link)
+	}
+	return kept
+}
+
+// isShortenedLink reports whether a redaction is a link flagged as shortened
+func isShortenedLink(redaction Redaction) bool {
+	shortened, _ := redaction.Metadata[shortenerMetadataKey].(bool)
+	return redaction.Type == TypeLink && shortened
+}
@@ -0,0 +1,73 @@
+package redaction
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestShortenedLinks(t *testing.T) {
+	engine := NewEngine()
+	links := func(text string) []Redaction {
+		t.Helper()
+		result, err := engine.RedactText(context.Background(), &Request{Text: text, Mode: ModeReplace})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		var found []Redaction
+		for _, redaction := range result.Redactions {
+			if redaction.Type == TypeLink {
+				found = append(found, redaction)
+			}
+		}
+		return found
+	}
+
+	found := links("See https://bit.ly/3xYz9Qa and https://example.com/docs or t.co/AbC123")
+	if len(found) != 3 {
+		t.Fatalf("Expected three links, got %+v", found)
+	}
+	if found[0].Original != "https://bit.ly/3xYz9Qa" || !isShortenedLink(found[0]) {
+		t.Errorf("Expected the bit.ly link to be flagged as a shortener, got %+v", found[0])
+	}
+	if found[1].Original != "https://example.com/docs" || isShortenedLink(found[1]) {
+		t.Errorf("Expected the normal link not to be flagged, got %+v", found[1])
+	}
+	if found[2].Original != "t.co/AbC123" || !isShortenedLink(found[2]) {
+		t.Errorf("Expected the bare t.co link to be flagged, got %+v", found[2])
+	}
+
+	engine.SetShortenerHosts([]string{"go.example.com"})
+	found = links("https://bit.ly/3xYz9Qa and https://go.example.com/x1")
+	if len(found) != 2 || isShortenedLink(found[0]) || !isShortenedLink(found[1]) {
+		t.Errorf("Expected only the configured host to be flagged, got %+v", found)
+	}
+
+	engine.SetShortenerHosts(nil)
+	if found := links("bit.ly/3xYz9Qa"); len(found) != 0 {
+		t.Errorf("Expected bare shortened links not to match once disabled, got %+v", found)
+	}
+}
+
+func TestRedactShortenedLinksInAttributes(t *testing.T) {
+	engine := NewEngine()
+	document := `<a href="https://bit.ly/3xYz9Qa">short</a> <a href="https://example.com/docs">docs</a>`
+
+	result, err := engine.RedactHTML(context.Background(), document, nil)
+	if err != nil {
+		t.Fatalf("RedactHTML failed: %v", err)
+	}
+	if !strings.Contains(result.RedactedText, "https://bit.ly/3xYz9Qa") {
+		t.Errorf("Expected attribute links to be kept by default, got %s", result.RedactedText)
+	}
+
+	result, err = engine.RedactHTML(context.Background(), document, &HTMLOptions{
+		Request: &Request{Mode: ModeReplace, Options: map[string]interface{}{"redact_shortened_links": true}},
+	})
+	if err != nil {
+		t.Fatalf("RedactHTML failed: %v", err)
+	}
+	if strings.Contains(result.RedactedText, "bit.ly") || !strings.Contains(result.RedactedText, "https://example.com/docs") {
+		t.Errorf("Expected only the shortened link to be redacted, got %s", result.RedactedText)
+	}
+}
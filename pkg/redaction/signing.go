@@ -0,0 +1,77 @@
+package redaction
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// tokenSignatureSeparator joins a restore token's identifier and signature
+const tokenSignatureSeparator = "."
+
+// minTokenSigningKeyLength is the shortest accepted token signing key
+const minTokenSigningKeyLength = 16
+
+// SetTokenSigningKey sets the key that signs the restore tokens of reversible
+// results. Signed tokens carry an HMAC-SHA256 of their identifier, and tokens
+// whose signature does not verify are rejected like unknown ones. Result
+// tokens issued before the key was set no longer restore. In-text and
+// fixed-width span tokens are not signed, as their format is fixed, and still
+// restore while a key is set.
+func (re *Engine) SetTokenSigningKey(key []byte) error {
+	if len(key) < minTokenSigningKeyLength {
+		return fmt.Errorf("token signing key must be at least %d bytes", minTokenSigningKeyLength)
+	}
+
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.tokenSigningKey = append([]byte(nil), key...)
+	return nil
+}
+
+// signToken appends the signature of id when a signing key is set
+func (re *Engine) signToken(id string) string {
+	re.mutex.RLock()
+	key := re.tokenSigningKey
+	re.mutex.RUnlock()
+
+	if len(key) == 0 {
+		return id
+	}
+	return id + tokenSignatureSeparator + hex.EncodeToString(tokenSignature(key, id))
+}
+
+// validTokenSignature reports whether a restore token carries a valid
+// signature, or true when no signing key is set. The signature is compared in
+// constant time so a forged token learns nothing from how long it took to
+// reject.
+func (re *Engine) validTokenSignature(token string) bool {
+	re.mutex.RLock()
+	key := re.tokenSigningKey
+	re.mutex.RUnlock()
+
+	if len(key) == 0 {
+		return true
+	}
+
+	id, signature, found := strings.Cut(token, tokenSignatureSeparator)
+	if !found {
+		return false
+	}
+	decoded, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(decoded, tokenSignature(key, id)) == 1
+}
+
+// tokenSignature returns the HMAC-SHA256 of a token identifier
+func tokenSignature(key []byte, id string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,118 @@
+package redaction
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestSignedRestoreTokens(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+
+	if err := engine.SetTokenSigningKey([]byte("short")); err == nil {
+		t.Error("Expected short signing keys to be rejected")
+	}
+	if err := engine.SetTokenSigningKey([]byte("0123456789abcdef0123456789abcdef")); err != nil {
+		t.Fatalf("SetTokenSigningKey failed: %v", err)
+	}
+
+	text := "Contact jane@example.com"
+	result, err := engine.RedactText(ctx, &Request{Text: text, Mode: ModeReplace, Reversible: true})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+	if !strings.Contains(result.Token, tokenSignatureSeparator) {
+		t.Fatalf("Expected a signed token, got %q", result.Token)
+	}
+
+	restored, err := engine.RestoreText(ctx, result.Token)
+	if err != nil || restored.OriginalText != text {
+		t.Fatalf("Expected signed token to restore, got %v, %v", restored, err)
+	}
+
+	// A forged signature fails exactly like an unknown token
+	id, signature, _ := strings.Cut(result.Token, tokenSignatureSeparator)
+	forged := id + tokenSignatureSeparator + strings.Repeat("0", len(signature))
+	_, forgedErr := engine.RestoreText(ctx, forged)
+	_, unknownErr := engine.RestoreText(ctx, "unknown")
+	if forgedErr == nil || unknownErr == nil || forgedErr.Error() != unknownErr.Error() {
+		t.Errorf("Expected identical errors for forged and unknown tokens, got %v and %v", forgedErr, unknownErr)
+	}
+}
+
+func TestSignedEngineRestoresSpanTokens(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+	if err := engine.SetTokenSigningKey([]byte("0123456789abcdef0123456789abcdef")); err != nil {
+		t.Fatalf("SetTokenSigningKey failed: %v", err)
+	}
+
+	text := "Contact jane@example.com, SSN 123-45-6789"
+	for _, options := range []map[string]interface{}{nil, {"preserve_length": true}} {
+		result, err := engine.RedactText(ctx, &Request{Text: text, Mode: ModeTokenize, Options: options})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+
+		restored, err := engine.RestoreInText(ctx, result.RedactedText)
+		if err != nil {
+			t.Fatalf("RestoreInText failed: %v", err)
+		}
+		if options == nil && (restored.OriginalText != text || restored.Metadata["restored"] != 2) {
+			t.Errorf("Expected both in-text tokens restored, got %q (%v)", restored.OriginalText, restored.Metadata)
+		}
+
+		for _, redaction := range result.Redactions {
+			span, err := engine.RestoreText(ctx, redaction.Token)
+			if err != nil || span.OriginalText != redaction.Original {
+				t.Errorf("Expected span token %q to restore %q, got %v, %v", redaction.Token, redaction.Original, span, err)
+			}
+		}
+	}
+}
+
+// TestTokenSignatureConstantTime guards against the signature check being
+// rewritten with a comparison that returns early on the first differing byte
+func TestTokenSignatureConstantTime(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "signing.go", nil, 0)
+	if err != nil {
+		t.Fatalf("Failed to parse signing.go: %v", err)
+	}
+
+	var verify *ast.FuncDecl
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Name.Name == "validTokenSignature" {
+			verify = fn
+		}
+	}
+	if verify == nil {
+		t.Fatal("validTokenSignature not found")
+	}
+
+	constantTime := false
+	ast.Inspect(verify.Body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.SelectorExpr:
+			if pkg, ok := node.X.(*ast.Ident); ok {
+				switch pkg.Name + "." + node.Sel.Name {
+				case "subtle.ConstantTimeCompare":
+					constantTime = true
+				case "bytes.Equal", "bytes.Compare", "strings.EqualFold":
+					t.Errorf("Signature compared with %s.%s", pkg.Name, node.Sel.Name)
+				}
+			}
+		case *ast.CallExpr:
+			if ident, ok := node.Fun.(*ast.Ident); ok && ident.Name == "string" {
+				t.Error("Signature converted to string for comparison")
+			}
+		}
+		return true
+	})
+	if !constantTime {
+		t.Error("Expected validTokenSignature to use subtle.ConstantTimeCompare")
+	}
+}
@@ -0,0 +1,232 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp/syntax"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// defaultStreamChunkSize is the number of bytes read per chunk when streaming
+	defaultStreamChunkSize = 64 * 1024
+
+	// defaultMaxMatchLength caps the seam overlap when a pattern has no upper
+	// bound on its match length (e.g. it contains + or *)
+	defaultMaxMatchLength = 256
+)
+
+// StreamOptions configures RedactStream
+type StreamOptions struct {
+	// Request is the template every chunk is redacted with: its types,
+	// modes, options and budget apply to the stream as a whole. Its Text is
+	// ignored and streams are not reversible. Nil means replace mode.
+	Request *Request `json:"request,omitempty"`
+
+	// ChunkSize is the number of bytes read per chunk (default 64KB)
+	ChunkSize int `json:"chunk_size,omitempty"`
+
+	// MaxMatchLength caps the overlap re-scanned across chunk seams. Patterns
+	// with a bounded match length use their own maximum when it is smaller.
+	// Defaults to 256 bytes.
+	MaxMatchLength int `json:"max_match_length,omitempty"`
+}
+
+// StreamResult summarises a streaming redaction
+type StreamResult struct {
+	Redactions   []Redaction `json:"redactions"` // Offsets are relative to the whole input
+	BytesRead    int64       `json:"bytes_read"`
+	BytesWritten int64       `json:"bytes_written"`
+	Errors       []string    `json:"errors,omitempty"` // Non-fatal detector and strategy failures
+	Truncated    bool        `json:"truncated,omitempty"`
+}
+
+// RedactStream redacts text read from r in chunks and writes the redacted
+// output to w. Text near the end of each chunk is held back and re-scanned
+// together with the next chunk, so matches straddling a chunk boundary are
+// still detected. The output is what RedactText would produce for the whole
+// input; when the request's budget is spent, the output stops where
+// RedactText would cut the text and the rest of the input is not read.
+func (re *Engine) RedactStream(ctx context.Context, r io.Reader, w io.Writer, opts *StreamOptions) (*StreamResult, error) {
+	if opts == nil {
+		opts = &StreamOptions{}
+	}
+
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = defaultStreamChunkSize
+	}
+	request := segmentRequest(opts.Request)
+	request.Text = ""
+	if err := re.checkRequest(ctx, request, 0); err != nil {
+		return nil, err
+	}
+	redactor := re.newSeamRedactor(request, re.maxMatchLength(opts.MaxMatchLength))
+
+	result := &StreamResult{Redactions: []Redaction{}}
+	buf := make([]byte, chunkSize)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		default:
+		}
+
+		n, readErr := r.Read(buf)
+		result.BytesRead += int64(n)
+		eof := errors.Is(readErr, io.EOF)
+		if readErr != nil && !eof {
+			return result, fmt.Errorf("error reading stream: %w", readErr)
+		}
+
+		_, output, redactions, errs := redactor.feed(ctx, string(buf[:n]), eof)
+		result.Redactions = append(result.Redactions, redactions...)
+		result.Errors = append(result.Errors, errorStrings(errs)...)
+
+		written, err := io.WriteString(w, output)
+		result.BytesWritten += int64(written)
+		if err != nil {
+			return result, fmt.Errorf("error writing stream: %w", err)
+		}
+
+		if redactor.truncated {
+			result.Truncated = true
+			return result, nil
+		}
+		if eof {
+			return result, nil
+		}
+	}
+}
+
+// RedactToReader redacts request.Text as RedactText does and returns the
+// redacted text as a reader, for use with io.Copy and other stream
+// processors. The text is redacted before the reader is returned, so errors
+// surface here rather than from Read; use RedactStream for input that
+// should not be held in memory.
+func (re *Engine) RedactToReader(ctx context.Context, request *Request) (io.Reader, error) {
+	result, err := re.RedactText(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return strings.NewReader(result.RedactedText), nil
+}
+
+// seamStart returns the offset of the last maxMatchLen bytes of text, moved
+// back to the start of a rune
+func seamStart(text string, maxMatchLen int) int {
+	start := len(text) - maxMatchLen
+	if start <= 0 {
+		return 0
+	}
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	return start
+}
+
+// applyRedactionsToText applies non-overlapping redactions in a single pass.
+// The caller's slice is left in its original order.
+func applyRedactionsToText(text string, redactions []Redaction) string {
+	ordered := make([]Redaction, len(redactions))
+	copy(ordered, redactions)
+	sortRedactionsAscending(ordered)
+
+	var builder strings.Builder
+	last := 0
+	for _, redaction := range ordered {
+		builder.WriteString(text[last:redaction.Start])
+		builder.WriteString(redaction.Replacement)
+		last = redaction.End
+	}
+	builder.WriteString(text[last:])
+
+	return builder.String()
+}
+
+// maxMatchLength returns the longest match any registered pattern can produce,
+// using limit (or defaultMaxMatchLength) for unbounded patterns and as a cap
+func (re *Engine) maxMatchLength(limit int) int {
+	if limit <= 0 {
+		limit = defaultMaxMatchLength
+	}
+
+	longest := 0
+	for _, pattern := range re.patternSnapshot() {
+		length := patternMaxLength(pattern.String())
+		if length < 0 || length > limit {
+			length = limit
+		}
+		longest = maxInt(longest, length)
+	}
+
+	return longest
+}
+
+// patternMaxLength returns the maximum match length of a regular expression
+// in bytes, or -1 if the match length is unbounded
+func patternMaxLength(expr string) int {
+	parsed, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return -1
+	}
+	return syntaxMaxLength(parsed)
+}
+
+// syntaxMaxLength walks a parsed regular expression computing its maximum match length
+func syntaxMaxLength(node *syntax.Regexp) int {
+	switch node.Op {
+	case syntax.OpLiteral:
+		length := 0
+		for _, r := range node.Rune {
+			length += runeMaxLength(r)
+		}
+		return length
+	case syntax.OpCharClass:
+		if len(node.Rune) == 0 {
+			return 0
+		}
+		return runeMaxLength(node.Rune[len(node.Rune)-1])
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return utf8.UTFMax
+	case syntax.OpStar, syntax.OpPlus:
+		return -1
+	case syntax.OpQuest, syntax.OpCapture:
+		return syntaxMaxLength(node.Sub[0])
+	case syntax.OpRepeat:
+		sub := syntaxMaxLength(node.Sub[0])
+		if node.Max < 0 || sub < 0 {
+			return -1
+		}
+		return sub * node.Max
+	case syntax.OpConcat, syntax.OpAlternate:
+		total := 0
+		for _, sub := range node.Sub {
+			length := syntaxMaxLength(sub)
+			if length < 0 {
+				return -1
+			}
+			if node.Op == syntax.OpConcat {
+				total += length
+			} else {
+				total = maxInt(total, length)
+			}
+		}
+		return total
+	default:
+		// Empty matches and assertions (anchors, word boundaries) consume nothing
+		return 0
+	}
+}
+
+// runeMaxLength returns the UTF-8 encoded length of r, treating invalid runes as maximal
+func runeMaxLength(r rune) int {
+	if length := utf8.RuneLen(r); length > 0 {
+		return length
+	}
+	return utf8.UTFMax
+}
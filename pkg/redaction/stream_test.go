@@ -0,0 +1,199 @@
+package redaction
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+)
+
+// chunkedReader returns its chunks one Read call at a time
+type chunkedReader struct {
+	chunks []string
+}
+
+func (r *chunkedReader) Read(p []byte) (int, error) {
+	if len(r.chunks) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.chunks[0])
+	r.chunks[0] = r.chunks[0][n:]
+	if r.chunks[0] == "" {
+		r.chunks = r.chunks[1:]
+	}
+	return n, nil
+}
+
+func TestSeamStart(t *testing.T) {
+	testCases := []struct {
+		name        string
+		text        string
+		maxMatchLen int
+		expected    int
+	}{
+		{name: "Text shorter than limit", text: "abc", maxMatchLen: 10, expected: 0},
+		{name: "Seam at limit", text: "0123456789", maxMatchLen: 4, expected: 6},
+		{name: "Seam moved back to rune start", text: "xxé", maxMatchLen: 1, expected: 2},
+		{name: "Empty text", text: "", maxMatchLen: 4, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := seamStart(tc.text, tc.maxMatchLen); got != tc.expected {
+				t.Errorf("Expected %d, got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestPatternMaxLength(t *testing.T) {
+	testCases := []struct {
+		expr     string
+		expected int
+	}{
+		{expr: `\b\d{3}-\d{2}-\d{4}\b`, expected: 11},
+		{expr: `\bGB\d{2}\s?[A-Z]{4}`, expected: 9},
+		{expr: `[a-z]+@example\.com`, expected: -1},
+	}
+
+	for _, tc := range testCases {
+		if got := patternMaxLength(tc.expr); got != tc.expected {
+			t.Errorf("patternMaxLength(%q): expected %d, got %d", tc.expr, tc.expected, got)
+		}
+	}
+}
+
+func TestRedactStreamDetectsMatchAcrossChunks(t *testing.T) {
+	engine := NewEngine()
+
+	first := "Please contact john.do"
+	second := "e@example.com about the invoice."
+	var output bytes.Buffer
+
+	result, err := engine.RedactStream(context.Background(),
+		&chunkedReader{chunks: []string{first, second}}, &output,
+		&StreamOptions{ChunkSize: len(first)})
+	if err != nil {
+		t.Fatalf("RedactStream failed: %v", err)
+	}
+
+	expected := "Please contact [EMAIL_REDACTED] about the invoice."
+	if output.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, output.String())
+	}
+
+	if len(result.Redactions) != 1 {
+		t.Fatalf("Expected 1 redaction, got %d", len(result.Redactions))
+	}
+	redaction := result.Redactions[0]
+	if redaction.Type != TypeEmail || redaction.Start != len("Please contact ") {
+		t.Errorf("Expected email at offset %d, got %s at %d", len("Please contact "), redaction.Type, redaction.Start)
+	}
+	if result.BytesRead != int64(len(first+second)) {
+		t.Errorf("Expected %d bytes read, got %d", len(first+second), result.BytesRead)
+	}
+}
+
+func TestRedactStreamMatchesRedactText(t *testing.T) {
+	engine := NewEngine()
+	text := strings.Repeat("Email test@example.com, SSN 123-45-6789, call 555-123-4567. ", 40)
+
+	expected, err := engine.RedactText(context.Background(), &Request{Text: text, Mode: ModeReplace})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+
+	var output bytes.Buffer
+	result, err := engine.RedactStream(context.Background(), strings.NewReader(text), &output,
+		&StreamOptions{ChunkSize: 37})
+	if err != nil {
+		t.Fatalf("RedactStream failed: %v", err)
+	}
+
+	if output.String() != expected.RedactedText {
+		t.Errorf("Streamed output differs from RedactText output")
+	}
+	if len(result.Redactions) != len(expected.Redactions) {
+		t.Errorf("Expected %d redactions, got %d", len(expected.Redactions), len(result.Redactions))
+	}
+}
+
+func TestRedactStreamUsesRequestTemplate(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+	text := strings.Repeat("Email test@example.com, SSN 123-45-6789, call 555-123-4567. ", 10)
+
+	testCases := []struct {
+		name    string
+		request Request
+	}{
+		{name: "Types", request: Request{Types: []Type{TypeEmail}}},
+		{name: "Type modes", request: Request{TypeModes: map[Type]Mode{TypeSSN: ModeMask}}},
+		{name: "Numbered placeholders", request: Request{Options: map[string]interface{}{"number_placeholders": "position"}}},
+		{name: "Replacer func", request: Request{ReplacerFunc: func(redaction Redaction) string {
+			return "<" + string(redaction.Type) + ">"
+		}}},
+		{name: "Redaction budget", request: Request{Options: map[string]interface{}{"max_redactions": 7}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			request := tc.request
+			request.Text = text
+			expected, err := engine.RedactText(ctx, &request)
+			if err != nil {
+				t.Fatalf("RedactText failed: %v", err)
+			}
+
+			var output bytes.Buffer
+			result, err := engine.RedactStream(ctx, strings.NewReader(text), &output,
+				&StreamOptions{Request: &tc.request, ChunkSize: 23})
+			if err != nil {
+				t.Fatalf("RedactStream failed: %v", err)
+			}
+
+			if output.String() != expected.RedactedText {
+				t.Errorf("Expected %q, got %q", expected.RedactedText, output.String())
+			}
+			if len(result.Redactions) != len(expected.Redactions) || result.Truncated != expected.Truncated {
+				t.Errorf("Expected %d redactions (truncated %v), got %d (truncated %v)",
+					len(expected.Redactions), expected.Truncated, len(result.Redactions), result.Truncated)
+			}
+		})
+	}
+
+	_, err := engine.RedactStream(ctx, strings.NewReader(text), &bytes.Buffer{},
+		&StreamOptions{Request: &Request{Types: []Type{"shoe_size"}}})
+	if err == nil || !strings.Contains(err.Error(), "unknown redaction type") {
+		t.Errorf("Expected the template to be validated, got %v", err)
+	}
+}
+
+func TestRedactToReader(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+	text := "Email test@example.com, SSN 123-45-6789, call 555-123-4567."
+
+	expected, err := engine.RedactText(ctx, &Request{Text: text, Mode: ModeReplace})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+
+	reader, err := engine.RedactToReader(ctx, &Request{Text: text, Mode: ModeReplace})
+	if err != nil {
+		t.Fatalf("RedactToReader failed: %v", err)
+	}
+
+	var output bytes.Buffer
+	if _, err := io.Copy(&output, reader); err != nil {
+		t.Fatalf("io.Copy failed: %v", err)
+	}
+	if output.String() != expected.RedactedText {
+		t.Errorf("Expected %q, got %q", expected.RedactedText, output.String())
+	}
+
+	if _, err := engine.RedactToReader(ctx, &Request{Text: text, Mode: Mode("bogus")}); err == nil {
+		t.Error("Expected an invalid mode to be rejected")
+	}
+}
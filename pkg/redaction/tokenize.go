@@ -0,0 +1,167 @@
+package redaction
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// tokenAlphabet is used for fixed-width tokens so they remain plain
+// alphanumerics whatever field they are written into
+const tokenAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// maxTokenAttempts bounds retries when a generated fixed-width token collides
+// with one already in the store
+const maxTokenAttempts = 16
+
+// ErrTokensExhausted is returned when no fixed-width token of a span's length
+// is free, as happens for short spans once most tokens of their width are in
+// use. The span keeps its placeholder rather than a token of another width.
+var ErrTokensExhausted = errors.New("no free fixed-width token")
+
+// spanTokenPattern matches the in-text markers minted by storeSpanToken
+var spanTokenPattern = regexp.MustCompile(`\[TOKEN_[0-9A-F]{16}\]`)
+
+// ContainsTokenMarkers reports whether text holds any in-text token markers
+func ContainsTokenMarkers(text string) bool {
+	return spanTokenPattern.MatchString(text)
+}
+
+// RestoreInText restores every in-text token marker in a redacted document.
+// Unknown or expired markers are left in place and listed under the
+// "unresolved" metadata key. Fixed-width tokens, minted under the
+// preserve_length option, are plain alphanumerics that cannot be told apart
+// from the surrounding text, so they are not restored here; pass them to
+// RestoreSpan or RestoreText one at a time.
+func (re *Engine) RestoreInText(ctx context.Context, text string) (*RestoreResult, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	restored := 0
+	unresolved := []string{}
+	originalText := spanTokenPattern.ReplaceAllStringFunc(text, func(marker string) string {
+		original, err := re.restoreTextInternal(marker)
+		if err != nil {
+			unresolved = append(unresolved, marker)
+			return marker
+		}
+		restored++
+		return original
+	})
+
+	return &RestoreResult{
+		OriginalText: originalText,
+		RestoredAt:   time.Now(),
+		Metadata: map[string]interface{}{
+			"provider":   "Engine",
+			"restored":   restored,
+			"unresolved": unresolved,
+		},
+	}, nil
+}
+
+// RestoreSpan returns the original of the single span a per-span token, as
+// minted by tokenize mode, stands for. Unlike RestoreText it refuses
+// whole-document tokens, so revealing one field never reveals the rest of
+// the document. Whether the caller may see the span is for the caller to
+// decide.
+func (re *Engine) RestoreSpan(ctx context.Context, token string) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	default:
+	}
+
+	if token == "" {
+		return "", ErrNotReversible
+	}
+
+	re.mutex.RLock()
+	tokenInfo, exists := re.tokens[token]
+	re.mutex.RUnlock()
+
+	if !exists || time.Now().After(tokenInfo.Expires) {
+		return "", fmt.Errorf("invalid or expired token")
+	}
+	if !tokenInfo.Span {
+		return "", fmt.Errorf("not a span token: use RestoreText for whole-document tokens")
+	}
+	return tokenInfo.OriginalText, nil
+}
+
+// storeSpanToken mints an in-text token marker for a single redaction and
+// records the original span in the token store
+func (re *Engine) storeSpanToken(redaction *Redaction, ttl time.Duration) string {
+	token := "[TOKEN_" + strings.ToUpper(randomHex(8)) + "]"
+	re.storeToken(token, redaction, ttl)
+	return token
+}
+
+// storeFixedWidthToken mints a token with the same character length as the
+// original span so fixed-width downstream fields stay valid. The token itself
+// is the store key, so it can be restored like any other token. When every
+// attempt collides with a stored token, as short spans can, it returns
+// ErrTokensExhausted rather than overwrite another span's original or break
+// the length guarantee.
+func (re *Engine) storeFixedWidthToken(redaction *Redaction, ttl time.Duration) (string, error) {
+	width := utf8.RuneCountInString(redaction.Original)
+
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	for attempt := 0; attempt < maxTokenAttempts; attempt++ {
+		token := randomAlphanumeric(width)
+		if _, exists := re.tokens[token]; !exists {
+			re.putTokenLocked(token, redaction, ttl)
+			return token, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: width %d", ErrTokensExhausted, width)
+}
+
+// storeToken records the original text of a redaction under the given token
+func (re *Engine) storeToken(token string, redaction *Redaction, ttl time.Duration) {
+	re.mutex.Lock()
+	defer re.mutex.Unlock()
+
+	re.putTokenLocked(token, redaction, ttl)
+}
+
+// putTokenLocked writes a token entry; the caller must hold re.mutex
+func (re *Engine) putTokenLocked(token string, redaction *Redaction, ttl time.Duration) {
+	now := time.Now()
+	re.tokens[token] = TokenInfo{
+		OriginalText: redaction.Original,
+		Type:         redaction.Type,
+		Created:      now,
+		Expires:      now.Add(ttl),
+		Span:         true,
+	}
+}
+
+// randomAlphanumeric returns a random string of n characters from
+// tokenAlphabet. Random bytes at or above the largest multiple of the
+// alphabet size are discarded, so every character is equally likely.
+func randomAlphanumeric(n int) string {
+	limit := 256 - 256%len(tokenAlphabet)
+	token := make([]byte, 0, n)
+	random := make([]byte, n)
+	for len(token) < n {
+		_, _ = rand.Read(random)
+		for _, b := range random {
+			if int(b) < limit && len(token) < n {
+				token = append(token, tokenAlphabet[int(b)%len(tokenAlphabet)])
+			}
+		}
+	}
+	return string(token)
+}
@@ -0,0 +1,270 @@
+package redaction
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestTokenizeMode(t *testing.T) {
+	engine := NewEngine()
+
+	result, err := engine.RedactText(context.Background(), &Request{
+		Text: "Email: test@example.com",
+		Mode: ModeTokenize,
+	})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+
+	if len(result.Redactions) != 1 {
+		t.Fatalf("Expected 1 redaction, got %d", len(result.Redactions))
+	}
+
+	redaction := result.Redactions[0]
+	if !strings.HasPrefix(redaction.Token, "[TOKEN_") {
+		t.Errorf("Expected token marker, got %q", redaction.Token)
+	}
+	if result.RedactedText != "Email: "+redaction.Token {
+		t.Errorf("Expected token in redacted text, got %s", result.RedactedText)
+	}
+
+	restored, err := engine.RestoreText(context.Background(), redaction.Token)
+	if err != nil {
+		t.Fatalf("RestoreText failed: %v", err)
+	}
+	if restored.OriginalText != "test@example.com" {
+		t.Errorf("Expected restored span 'test@example.com', got %q", restored.OriginalText)
+	}
+}
+
+func TestLengthPreservingTokenize(t *testing.T) {
+	engine := NewEngine()
+
+	testCases := []struct {
+		name     string
+		text     string
+		original string
+	}{
+		{name: "SSN", text: "SSN: 123-45-6789", original: "123-45-6789"},
+		{name: "Email", text: "Email: test@example.com", original: "test@example.com"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := engine.RedactText(context.Background(), &Request{
+				Text:    tc.text,
+				Mode:    ModeTokenize,
+				Options: map[string]interface{}{"preserve_length": true},
+			})
+			if err != nil {
+				t.Fatalf("RedactText failed: %v", err)
+			}
+
+			if len(result.Redactions) != 1 {
+				t.Fatalf("Expected 1 redaction, got %d", len(result.Redactions))
+			}
+
+			token := result.Redactions[0].Token
+			if utf8.RuneCountInString(token) != utf8.RuneCountInString(tc.original) {
+				t.Errorf("Expected %d-char token, got %q", utf8.RuneCountInString(tc.original), token)
+			}
+			if len(result.RedactedText) != len(tc.text) {
+				t.Errorf("Expected redacted text length %d, got %d", len(tc.text), len(result.RedactedText))
+			}
+
+			restored, err := engine.RestoreText(context.Background(), token)
+			if err != nil {
+				t.Fatalf("RestoreText failed: %v", err)
+			}
+			if restored.OriginalText != tc.original {
+				t.Errorf("Expected restored span %q, got %q", tc.original, restored.OriginalText)
+			}
+		})
+	}
+}
+
+// TestFixedWidthTokenCollisions tests that a span whose fixed-width tokens
+// are all taken is refused instead of overwriting another span or getting a
+// token of another width
+func TestFixedWidthTokenCollisions(t *testing.T) {
+	engine := NewEngine()
+
+	// Every one-character token is already taken
+	for _, c := range tokenAlphabet {
+		engine.storeToken(string(c), &Redaction{Original: "taken-" + string(c), Type: TypeCustom}, time.Hour)
+	}
+
+	token, err := engine.storeFixedWidthToken(&Redaction{Original: "x", Type: TypeCustom}, time.Hour)
+	if !errors.Is(err, ErrTokensExhausted) || token != "" {
+		t.Fatalf("Expected ErrTokensExhausted, got %q, %v", token, err)
+	}
+	for _, c := range tokenAlphabet {
+		if restored, err := engine.RestoreSpan(context.Background(), string(c)); err != nil || restored != "taken-"+string(c) {
+			t.Fatalf("Expected token %c to keep its original, got %q, %v", c, restored, err)
+		}
+	}
+
+	// RedactText reports the failure and keeps the placeholder
+	result, err := engine.RedactText(context.Background(), &Request{
+		Text:           "Code Q here",
+		Mode:           ModeTokenize,
+		Options:        map[string]interface{}{"preserve_length": true},
+		CustomPatterns: []CustomPattern{{Name: "code", Pattern: `\bQ\b`}},
+	})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+	if result.RedactedText != "Code [CUSTOM_REDACTED] here" || len(result.Errors) != 1 {
+		t.Errorf("Expected the placeholder and one error, got %q, %v", result.RedactedText, result.Errors)
+	}
+}
+
+func TestRandomAlphanumeric(t *testing.T) {
+	token := randomAlphanumeric(4096)
+	if len(token) != 4096 {
+		t.Fatalf("Expected 4096 characters, got %d", len(token))
+	}
+	for _, c := range token {
+		if !strings.ContainsRune(tokenAlphabet, c) {
+			t.Fatalf("Unexpected character %q", c)
+		}
+	}
+}
+
+func TestRestoreInText(t *testing.T) {
+	engine := NewEngine()
+	original := "Call 555-123-4567 or mail test@example.com"
+
+	result, err := engine.RedactText(context.Background(), &Request{
+		Text: original,
+		Mode: ModeTokenize,
+	})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+
+	restored, err := engine.RestoreInText(context.Background(), result.RedactedText+" [TOKEN_0123456789ABCDEF]")
+	if err != nil {
+		t.Fatalf("RestoreInText failed: %v", err)
+	}
+
+	if restored.OriginalText != original+" [TOKEN_0123456789ABCDEF]" {
+		t.Errorf("Unexpected restored text: %q", restored.OriginalText)
+	}
+	if restored.Metadata["restored"] != 2 {
+		t.Errorf("Expected 2 restored markers, got %v", restored.Metadata["restored"])
+	}
+	if unresolved, _ := restored.Metadata["unresolved"].([]string); len(unresolved) != 1 {
+		t.Errorf("Expected the unknown marker to be unresolved, got %v", restored.Metadata["unresolved"])
+	}
+}
+
+func TestRestoreSpan(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+
+	result, err := engine.RedactText(ctx, &Request{
+		Text:       "Call 555-123-4567 or mail test@example.com, SSN 123-45-6789",
+		Mode:       ModeTokenize,
+		Reversible: true,
+	})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+	if len(result.Redactions) != 3 {
+		t.Fatalf("Expected 3 redactions, got %+v", result.Redactions)
+	}
+
+	// Only the requested span is revealed
+	email := result.Redactions[1]
+	original, err := engine.RestoreSpan(ctx, email.Token)
+	if err != nil {
+		t.Fatalf("RestoreSpan failed: %v", err)
+	}
+	if original != "test@example.com" {
+		t.Errorf("Expected only the email, got %q", original)
+	}
+
+	if _, err := engine.RestoreSpan(ctx, result.Token); err == nil {
+		t.Error("Expected the whole-document token to be refused")
+	}
+	if _, err := engine.RestoreSpan(ctx, "[TOKEN_0123456789ABCDEF]"); err == nil {
+		t.Error("Expected an unknown token to be refused")
+	}
+	if _, err := engine.RestoreSpan(ctx, ""); !errors.Is(err, ErrNotReversible) {
+		t.Errorf("Expected ErrNotReversible for an empty token, got %v", err)
+	}
+}
+
+func TestExpiredTokensRestoreNowhere(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+
+	result, err := engine.RedactText(ctx, &Request{
+		Text:       "mail test@example.com",
+		Mode:       ModeTokenize,
+		Reversible: true,
+	})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+
+	// Expire every token without waiting for cleanup to remove them
+	engine.mutex.Lock()
+	for token, info := range engine.tokens {
+		info.Expires = time.Now().Add(-time.Minute)
+		engine.tokens[token] = info
+	}
+	engine.mutex.Unlock()
+
+	span := result.Redactions[0].Token
+	if _, err := engine.RestoreSpan(ctx, span); err == nil {
+		t.Error("Expected RestoreSpan to refuse an expired token")
+	}
+	if _, err := engine.RestoreText(ctx, span); err == nil {
+		t.Error("Expected RestoreText to refuse an expired span token")
+	}
+	if _, err := engine.RestoreText(ctx, result.Token); err == nil {
+		t.Error("Expected RestoreText to refuse an expired result token")
+	}
+	restored, err := engine.RestoreInText(ctx, result.RedactedText)
+	if err != nil {
+		t.Fatalf("RestoreInText failed: %v", err)
+	}
+	if restored.OriginalText != result.RedactedText || restored.Metadata["restored"] != 0 {
+		t.Errorf("Expected the expired marker to stay, got %q", restored.OriginalText)
+	}
+}
+
+// TestRestoreInTextFixedWidthTokens tests that fixed-width tokens, which
+// carry no marker, are left to RestoreSpan
+func TestRestoreInTextFixedWidthTokens(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+
+	result, err := engine.RedactText(ctx, &Request{
+		Text:    "SSN 123-45-6789 on file",
+		Mode:    ModeTokenize,
+		Options: map[string]interface{}{"preserve_length": true},
+	})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+
+	restored, err := engine.RestoreInText(ctx, result.RedactedText)
+	if err != nil {
+		t.Fatalf("RestoreInText failed: %v", err)
+	}
+	if restored.OriginalText != result.RedactedText || restored.Metadata["restored"] != 0 {
+		t.Errorf("Expected fixed-width tokens to be left in place, got %q", restored.OriginalText)
+	}
+
+	span, err := engine.RestoreSpan(ctx, result.Redactions[0].Token)
+	if err != nil || span != "123-45-6789" {
+		t.Errorf("Expected RestoreSpan to restore the token, got %q, %v", span, err)
+	}
+}
@@ -854,3 +854,77 @@ func TestUKComplianceIntegration(t *testing.T) {
 	t.Logf("Successfully detected and redacted %d UK-specific identifiers", len(ukPatternCounts))
 	t.Logf("Original text length: %d, Redacted text length: %d", len(text), len(result.RedactedText))
 }
+
+// TestUKContextDependentConfidence tests that keyword-less matches of context-dependent types get lower confidence
+func TestUKContextDependentConfidence(t *testing.T) {
+	engine := NewEngine()
+
+	testCases := []struct {
+		name         string
+		text         string
+		expectedType Type
+		confidence   float64
+	}{
+		{
+			name:         "Passport with keyword",
+			text:         "Passport No: 123456789",
+			expectedType: TypeUKPassportNumber,
+			confidence:   defaultConfidence,
+		},
+		{
+			name:         "Bare passport number",
+			text:         "Reference 123456789 attached",
+			expectedType: TypeUKPassportNumber,
+			confidence:   keywordAbsentConfidence,
+		},
+		{
+			name:         "Company number with keyword",
+			text:         "Company Number: 12345678",
+			expectedType: TypeUKCompanyNumber,
+			confidence:   defaultConfidence,
+		},
+		{
+			name:         "Bare company number",
+			text:         "Order 12345678 shipped",
+			expectedType: TypeUKCompanyNumber,
+			confidence:   keywordAbsentConfidence,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := engine.RedactText(context.Background(), &Request{
+				Text: tc.text,
+				Mode: ModeReplace,
+			})
+			if err != nil {
+				t.Fatalf("RedactText failed: %v", err)
+			}
+
+			if len(result.Redactions) != 1 || result.Redactions[0].Type != tc.expectedType {
+				t.Fatalf("Expected a single %s redaction, got %v", tc.expectedType, getRedactionTypes(result.Redactions))
+			}
+			if result.Redactions[0].Confidence != tc.confidence {
+				t.Errorf("Expected confidence %.2f, got %.2f", tc.confidence, result.Redactions[0].Confidence)
+			}
+		})
+	}
+
+	t.Run("MinConfidence drops bare matches", func(t *testing.T) {
+		result, err := engine.RedactText(context.Background(), &Request{
+			Text:          "Passport No: 123456789, reference 987654321",
+			Mode:          ModeReplace,
+			MinConfidence: 0.8,
+		})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+
+		if len(result.Redactions) != 1 {
+			t.Fatalf("Expected 1 redaction, got %d", len(result.Redactions))
+		}
+		if !strings.Contains(result.RedactedText, "987654321") {
+			t.Errorf("Expected bare number to be kept, got %s", result.RedactedText)
+		}
+	})
+}
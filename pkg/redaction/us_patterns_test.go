@@ -0,0 +1,150 @@
+package redaction
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestUSPassportNumbers tests US passport number detection
+func TestUSPassportNumbers(t *testing.T) {
+	engine := NewEngine()
+
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "Passport book number with US keyword",
+			text:     "US Passport No: 123456789 on file",
+			expected: "US Passport No: 123456789",
+		},
+		{
+			name:     "Next generation number with keyword",
+			text:     "U.S. Passport Number A12345678 renewed",
+			expected: "U.S. Passport Number A12345678",
+		},
+		{
+			name:     "Next generation number with generic keyword",
+			text:     "Passport #: C03005988",
+			expected: "Passport #: C03005988",
+		},
+		{
+			name:     "Bare next generation number",
+			text:     "Document A12345678 scanned",
+			expected: "A12345678",
+		},
+		{
+			name:     "Bare nine digits are not a US passport",
+			text:     "Document 123456789 scanned",
+			expected: "",
+		},
+		{
+			name:     "Too many digits",
+			text:     "Code A123456789 scanned",
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := engine.RedactText(context.Background(), &Request{
+				Text: tc.text,
+				Mode: ModeReplace,
+			})
+			if err != nil {
+				t.Fatalf("RedactText failed: %v", err)
+			}
+
+			var found []string
+			for _, redaction := range result.Redactions {
+				if redaction.Type == TypeUSPassport {
+					found = append(found, redaction.Original)
+				}
+			}
+
+			if tc.expected == "" {
+				if len(found) != 0 {
+					t.Errorf("Expected no US passport numbers, got %v for text: %s", found, tc.text)
+				}
+				return
+			}
+			if len(found) != 1 || found[0] != tc.expected {
+				t.Errorf("Expected %q, got %v (all: %v)", tc.expected, found, getRedactionTypes(result.Redactions))
+			}
+		})
+	}
+}
+
+// TestUSMilitaryIDs tests DoD ID number detection
+func TestUSMilitaryIDs(t *testing.T) {
+	engine := NewEngine()
+
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"DoD ID keyword", "Member DoD ID: 1234567890 reported", "DoD ID: 1234567890"},
+		{"EDIPI keyword", "EDIPI 1029384756 on the roster", "EDIPI 1029384756"},
+		{"Military ID number keyword", "Military ID Number 5647382910", "Military ID Number 5647382910"},
+		{"Bare ten digits", "Reference 1234567890 attached", ""},
+		{"Too few digits", "DoD ID: 123456789", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := engine.RedactText(context.Background(), &Request{
+				Text: tc.text,
+				Mode: ModeReplace,
+			})
+			if err != nil {
+				t.Fatalf("RedactText failed: %v", err)
+			}
+
+			var found []string
+			for _, redaction := range result.Redactions {
+				if redaction.Type == TypeMilitaryID {
+					found = append(found, redaction.Original)
+				}
+			}
+
+			if tc.expected == "" {
+				if len(found) != 0 {
+					t.Errorf("Expected no military IDs, got %v for text: %s", found, tc.text)
+				}
+				return
+			}
+			if len(found) != 1 || found[0] != tc.expected {
+				t.Errorf("Expected %q, got %v (all: %v)", tc.expected, found, getRedactionTypes(result.Redactions))
+			}
+			if !strings.Contains(result.RedactedText, "[MILITARY_ID_REDACTED]") {
+				t.Errorf("Expected a military ID placeholder, got %s", result.RedactedText)
+			}
+		})
+	}
+}
+
+// TestRequireContext tests that the require_context option drops keywordless
+// matches of context-dependent types
+func TestRequireContext(t *testing.T) {
+	engine := NewEngine()
+	text := "US Passport No: B98765432, document A12345678, reference 987654321"
+
+	result, err := engine.RedactText(context.Background(), &Request{
+		Text:    text,
+		Mode:    ModeReplace,
+		Options: map[string]interface{}{"require_context": true},
+	})
+	if err != nil {
+		t.Fatalf("RedactText failed: %v", err)
+	}
+
+	if len(result.Redactions) != 1 || result.Redactions[0].Type != TypeUSPassport {
+		t.Fatalf("Expected only the keyworded passport, got %v", getRedactionTypes(result.Redactions))
+	}
+	if !strings.Contains(result.RedactedText, "A12345678") || !strings.Contains(result.RedactedText, "987654321") {
+		t.Errorf("Expected bare numbers to be kept, got %s", result.RedactedText)
+	}
+}
@@ -0,0 +1,75 @@
+package redaction
+
+// validatedOnlyOption, when true, keeps number matches only when they are
+// confirmed by a validator or a keyword: matches of context-dependent types
+// found without their keyword are dropped, and SSN and credit card matches
+// must pass identifierChecks. Types with their own validator, such as
+// routing numbers and ITINs, are already validated.
+const validatedOnlyOption = "validated_only"
+
+// identifierChecks validate the digits of types whose patterns match any
+// number of the right shape
+var identifierChecks = map[Type]Validator{
+	TypeSSN:        isSSN,
+	TypeCreditCard: isLuhnValid,
+}
+
+// filterValidated drops the matches the validated_only option suppresses
+func filterValidated(redactions []Redaction) []Redaction {
+	kept := redactions[:0]
+	for _, redaction := range redactions {
+		if isKeywordlessGuess(redaction) {
+			continue
+		}
+		if check, exists := identifierChecks[redaction.Type]; exists && !check(redaction.Original) {
+			continue
+		}
+		kept = append(kept, redaction)
+	}
+	return kept
+}
+
+// isSSN rejects numbers the SSA never issues: area 000, 666 or 900-999,
+// group 00 and serial 0000
+func isSSN(match string) bool {
+	digits := onlyDigits(match)
+	if len(digits) != 9 {
+		return false
+	}
+
+	area, group, serial := digits[:3], digits[3:5], digits[5:]
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+// isLuhnValid checks the digits of a match pass the Luhn checksum used by
+// payment card numbers
+func isLuhnValid(match string) bool {
+	digits := onlyDigits(match)
+	if len(digits) < 2 {
+		return false
+	}
+
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if (len(digits)-i)%2 == 0 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return sum%10 == 0
+}
+
+// onlyDigits returns the ASCII digits of s, dropping separators
+func onlyDigits(s string) string {
+	digits := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			digits = append(digits, s[i])
+		}
+	}
+	return string(digits)
+}
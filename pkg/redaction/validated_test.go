@@ -0,0 +1,59 @@
+package redaction
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestValidatedOnly(t *testing.T) {
+	engine := NewEngine()
+	ctx := context.Background()
+	text := "Order 123456789 shipped, order 12345678 packed, order 4111 1111 1111 1112 held, " +
+		"order 000-12-3456 cancelled. Paid with 4111 1111 1111 1111 from routing 021000021, SSN 123-45-6789."
+
+	originals := func(options map[string]interface{}) []string {
+		result, err := engine.RedactText(ctx, &Request{Text: text, Mode: ModeReplace, Options: options})
+		if err != nil {
+			t.Fatalf("RedactText failed: %v", err)
+		}
+		var found []string
+		for _, redaction := range result.Redactions {
+			found = append(found, redaction.Original)
+		}
+		sort.Strings(found)
+		return found
+	}
+
+	if found := originals(nil); len(found) <= 3 {
+		t.Fatalf("Expected order numbers to match structurally by default, got %v", found)
+	}
+
+	expected := []string{"021000021", "123-45-6789", "4111 1111 1111 1111"}
+	if found := originals(map[string]interface{}{"validated_only": true}); !reflect.DeepEqual(found, expected) {
+		t.Errorf("Expected only validated identifiers %v, got %v", expected, found)
+	}
+}
+
+func TestIdentifierChecks(t *testing.T) {
+	tests := []struct {
+		check Validator
+		match string
+		valid bool
+	}{
+		{isLuhnValid, "4111 1111 1111 1111", true},
+		{isLuhnValid, "4111-1111-1111-1112", false},
+		{isSSN, "123-45-6789", true},
+		{isSSN, "000-12-3456", false},
+		{isSSN, "666-12-3456", false},
+		{isSSN, "912-34-5678", false},
+		{isSSN, "123-00-4567", false},
+		{isSSN, "123-45-0000", false},
+	}
+	for _, tt := range tests {
+		if got := tt.check(tt.match); got != tt.valid {
+			t.Errorf("check(%q) = %v, want %v", tt.match, got, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package redaction
+
+import (
+	"regexp"
+	"strings"
+)
+
+// vinWeights are the ISO 3779 position weights; position 9 holds the check
+// digit and has weight 0
+var vinWeights = [17]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// initVehiclePatterns initializes vehicle identifier patterns
+func (re *Engine) initVehiclePatterns() {
+	// Vehicle Identification Number: 17 letters and digits, never I, O or Q,
+	// with a valid check digit, see isVIN
+	// Format: 1M8GDM9AXKP042788
+	re.patterns[TypeVIN] = regexp.MustCompile(`\b[A-HJ-NPR-Z0-9]{17}\b`)
+	re.validators[TypeVIN] = isVIN
+
+	// License plate: up to 8 capital letters and digits, optionally split by
+	// a space or dash, only after a license plate or plate number keyword,
+	// which is redacted with the plate
+	// Format: License Plate: 7ABC123
+	re.patterns[TypeLicensePlate] = regexp.MustCompile(
+		`(?i:\b(?:Licen[cs]e\s+Plate(?:\s+(?:No\.?|Number|#))?|(?:Plate|Registration)\s+(?:No\.?|Number|#)))` +
+			`\s*[:#]?\s*[A-Z0-9]{1,4}[ -]?[A-Z0-9]{1,4}\b`)
+	re.validators[TypeLicensePlate] = isLicensePlate
+}
+
+// isVIN checks the ISO 3779 check digit of a VIN: the weighted sum of the
+// transliterated characters modulo 11, with 10 written as X. All-digit
+// matches are rejected, as every VIN's manufacturer code has a letter.
+func isVIN(match string) bool {
+	if len(match) != len(vinWeights) || !strings.ContainsAny(match, "ABCDEFGHJKLMNPRSTUVWXYZ") {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < len(match); i++ {
+		value, ok := vinValue(match[i])
+		if !ok {
+			return false
+		}
+		sum += value * vinWeights[i]
+	}
+
+	check := byte('0' + sum%11)
+	if sum%11 == 10 {
+		check = 'X'
+	}
+	return match[8] == check
+}
+
+// vinValue transliterates a VIN character to its numeric value
+func vinValue(c byte) (int, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0'), true
+	case c >= 'A' && c <= 'H':
+		return int(c-'A') + 1, true
+	case c >= 'J' && c <= 'N':
+		return int(c-'J') + 1, true
+	case c == 'P':
+		return 7, true
+	case c == 'R':
+		return 9, true
+	case c >= 'S' && c <= 'Z':
+		return int(c-'S') + 2, true
+	}
+	return 0, false
+}
+
+// isLicensePlate rejects keyword matches whose plate has no digit, such as
+// "Plate No: TBD"; the keyword itself never contains one
+func isLicensePlate(match string) bool {
+	return strings.ContainsAny(match, "0123456789")
+}
@@ -0,0 +1,57 @@
+package redaction
+
+import (
+	"context"
+	"testing"
+)
+
+// TestVehicleIdentifiers tests VIN and license plate detection
+func TestVehicleIdentifiers(t *testing.T) {
+	engine := NewEngine()
+
+	testCases := []struct {
+		name         string
+		text         string
+		expectedType Type
+		expected     string
+	}{
+		{"VIN with valid check digit", "Vehicle 1M8GDM9AXKP042788 was serviced", TypeVIN, "1M8GDM9AXKP042788"},
+		{"VIN with numeric check digit", "VIN: 1HGCM82633A004352", TypeVIN, "1HGCM82633A004352"},
+		{"VIN with invalid check digit", "Vehicle 1M8GDM9A1KP042788 was serviced", TypeVIN, ""},
+		{"VIN with excluded letter", "Vehicle 1M8GDM9AXKO042788 was serviced", TypeVIN, ""},
+		{"All-digit VIN-length number", "Reference 11111111111111111 filed", TypeVIN, ""},
+		{"License plate keyword", "License Plate: 7ABC123 towed", TypeLicensePlate, "License Plate: 7ABC123"},
+		{"Plate number with space", "Registration No. AB12 CDE", TypeLicensePlate, "Registration No. AB12 CDE"},
+		{"Plate without a digit", "Plate No: TBD", TypeLicensePlate, ""},
+		{"Bare plate", "Parked car 7ABC123 outside", TypeLicensePlate, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := engine.RedactText(context.Background(), &Request{
+				Text: tc.text,
+				Mode: ModeReplace,
+			})
+			if err != nil {
+				t.Fatalf("RedactText failed: %v", err)
+			}
+
+			var found []string
+			for _, redaction := range result.Redactions {
+				if redaction.Type == tc.expectedType {
+					found = append(found, redaction.Original)
+				}
+			}
+
+			if tc.expected == "" {
+				if len(found) != 0 {
+					t.Errorf("Expected no %s matches, got %v for text: %s", tc.expectedType, found, tc.text)
+				}
+				return
+			}
+			if len(found) != 1 || found[0] != tc.expected {
+				t.Errorf("Expected %q, got %v (all: %v)", tc.expected, found, getRedactionTypes(result.Redactions))
+			}
+		})
+	}
+}
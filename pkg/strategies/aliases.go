@@ -0,0 +1,47 @@
+package strategies
+
+import (
+	"strings"
+	"sync"
+)
+
+// typeAliases maps alternative detected-type names (including the redaction
+// engine's Type values) to the canonical names strategies switch on
+var (
+	aliasMu     sync.RWMutex
+	typeAliases = map[string]string{
+		"social_security":    "ssn",
+		"social_security_no": "ssn",
+		"credit_card_number": "credit_card",
+		"email_address":      "email",
+		"phone_number":       "phone",
+		"uk_phone_number":    "phone",
+		"uk_mobile_number":   "phone",
+		"person_name":        "name",
+		"zip_code":           "zip",
+		"postal_code":        "zip",
+		"organization":       "company",
+	}
+)
+
+// AliasFor returns the canonical detected type for the given name. Names are
+// case-insensitive; names without a registered alias are returned lower-cased.
+func AliasFor(detectedType string) string {
+	normalized := strings.ToLower(strings.TrimSpace(detectedType))
+
+	aliasMu.RLock()
+	defer aliasMu.RUnlock()
+
+	if canonical, exists := typeAliases[normalized]; exists {
+		return canonical
+	}
+	return normalized
+}
+
+// RegisterAlias maps an additional detected-type name onto a canonical type
+func RegisterAlias(alias, canonical string) {
+	aliasMu.Lock()
+	defer aliasMu.Unlock()
+
+	typeAliases[strings.ToLower(alias)] = strings.ToLower(canonical)
+}
@@ -0,0 +1,93 @@
+package strategies
+
+import (
+	"context"
+	"regexp"
+	"testing"
+)
+
+func TestAliasFor(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "ssn", expected: "ssn"},
+		{input: "social_security", expected: "ssn"},
+		{input: "Credit_Card_Number", expected: "credit_card"},
+		{input: "zip_code", expected: "zip"},
+		{input: "uk_mobile_number", expected: "phone"},
+		{input: "unregistered", expected: "unregistered"},
+	}
+
+	for _, tc := range testCases {
+		if got := AliasFor(tc.input); got != tc.expected {
+			t.Errorf("AliasFor(%q): expected %q, got %q", tc.input, tc.expected, got)
+		}
+	}
+}
+
+func TestAliasesRouteToSameStrategyBehavior(t *testing.T) {
+	ctx := context.Background()
+	ssnFormat := regexp.MustCompile(`^\d{3}-\d{2}-\d{4}$`)
+
+	t.Run("Format preserving", func(t *testing.T) {
+		strategy := NewFormatPreservingStrategy()
+		for _, detectedType := range []string{"ssn", "social_security"} {
+			result, err := strategy.Replace(ctx, &ReplacementRequest{
+				OriginalText: "123-45-6789",
+				DetectedType: detectedType,
+			})
+			if err != nil {
+				t.Fatalf("Replace failed: %v", err)
+			}
+			if !ssnFormat.MatchString(result.ReplacedText) || result.Confidence != 0.9 {
+				t.Errorf("Type %q did not use the SSN branch: %q (confidence %.2f)",
+					detectedType, result.ReplacedText, result.Confidence)
+			}
+		}
+	})
+
+	t.Run("Engine type name", func(t *testing.T) {
+		result, err := NewFormatPreservingStrategy().Replace(ctx, &ReplacementRequest{
+			OriginalText: "12345-6789",
+			DetectedType: "zip_code",
+		})
+		if err != nil {
+			t.Fatalf("Replace failed: %v", err)
+		}
+		if !regexp.MustCompile(`^\d{5}-\d{4}$`).MatchString(result.ReplacedText) {
+			t.Errorf("Expected ZIP+4 format for zip_code, got %q", result.ReplacedText)
+		}
+	})
+
+	t.Run("Consistent hash", func(t *testing.T) {
+		strategy := NewConsistentHashStrategy()
+		var outputs []string
+		for _, detectedType := range []string{"ssn", "social_security"} {
+			result, err := strategy.Replace(ctx, &ReplacementRequest{
+				OriginalText: "123-45-6789",
+				DetectedType: detectedType,
+			})
+			if err != nil {
+				t.Fatalf("Replace failed: %v", err)
+			}
+			outputs = append(outputs, result.ReplacedText)
+		}
+		if outputs[0] != outputs[1] {
+			t.Errorf("Expected aliases to hash identically, got %q and %q", outputs[0], outputs[1])
+		}
+	})
+
+	t.Run("Registry defaults", func(t *testing.T) {
+		registry := NewDefaultStrategyRegistry()
+		for _, detectedType := range []string{"ssn", "social_security", "SOCIAL_SECURITY"} {
+			strategy, err := registry.GetDefaultStrategy(detectedType)
+			if err != nil {
+				t.Fatalf("GetDefaultStrategy failed: %v", err)
+			}
+			if strategy.GetName() != "format_preserving" {
+				t.Errorf("Expected format_preserving for %q, got %s", detectedType, strategy.GetName())
+			}
+		}
+	})
+}
@@ -1,12 +1,15 @@
 // Package strategies provides various replacement strategies for redacted data.
-// It includes consistent hash, fake data, format preserving, random, and semantic strategies.
+// It includes consistent hash, fake data, format preserving, random, semantic and
+// structured placeholder strategies.
 package strategies
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"sync"
 )
 
 // ConsistentHashStrategy replaces sensitive data with consistent hash values
@@ -15,11 +18,32 @@ type ConsistentHashStrategy struct {
 	salt string
 }
 
+var (
+	processSaltOnce sync.Once
+	processSalt     string
+)
+
+// randomProcessSalt returns a salt generated once per process, so unsalted
+// strategies agree with each other but not with other runs
+func randomProcessSalt() string {
+	processSaltOnce.Do(func() {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			panic(fmt.Sprintf("strategies: generating hash salt: %v", err))
+		}
+		processSalt = hex.EncodeToString(b)
+	})
+	return processSalt
+}
+
 // NewConsistentHashStrategy creates a new consistent hash replacement strategy
+// with a random salt generated once per process. Hashes are consistent within
+// the process but differ between runs; use NewConsistentHashStrategyWithSalt
+// when values must match across runs or processes.
 func NewConsistentHashStrategy() *ConsistentHashStrategy {
 	return &ConsistentHashStrategy{
 		name: "consistent_hash",
-		salt: "default_salt_change_in_production", // Should be configurable in production
+		salt: randomProcessSalt(),
 	}
 }
 
@@ -47,11 +71,20 @@ func (s *ConsistentHashStrategy) Replace(_ context.Context, request *Replacement
 		return nil, fmt.Errorf("replacement request cannot be nil")
 	}
 
+	// Hash and format on the canonical type so aliases share an identity
+	detectedType := AliasFor(request.DetectedType)
+
+	// Subaddresses of one mailbox hash alike when normalization is requested
+	original := request.OriginalText
+	if detectedType == "email" && normalizePlusAddressRequested(request.Options) {
+		original = NormalizePlusAddress(original)
+	}
+
 	// Create a consistent hash of the original text
-	hash := s.createConsistentHash(request.OriginalText, request.DetectedType)
+	hash := s.createConsistentHash(original, detectedType)
 
 	// Format the hash based on the detected type and options
-	replacedText := s.formatHashForType(hash, request.DetectedType, request.Options)
+	replacedText := s.formatHashForType(hash, detectedType, request.Options)
 
 	return &ReplacementResult{
 		ReplacedText: replacedText,
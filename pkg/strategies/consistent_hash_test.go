@@ -0,0 +1,23 @@
+package strategies
+
+import "testing"
+
+func TestConsistentHashDefaultSalt(t *testing.T) {
+	first := NewConsistentHashStrategy()
+	second := NewConsistentHashStrategy()
+
+	if first.GetSalt() == "" || first.GetSalt() == "default_salt_change_in_production" {
+		t.Fatalf("expected a random salt, got %q", first.GetSalt())
+	}
+	if first.GetSalt() != second.GetSalt() {
+		t.Error("expected unsalted strategies in one process to share a salt")
+	}
+	if first.createConsistentHash("jane@example.com", "email") != second.createConsistentHash("jane@example.com", "email") {
+		t.Error("expected consistent hashes within the process")
+	}
+
+	salted := NewConsistentHashStrategyWithSalt("pepper")
+	if salted.createConsistentHash("jane@example.com", "email") == first.createConsistentHash("jane@example.com", "email") {
+		t.Error("expected a configured salt to change the hash")
+	}
+}
@@ -0,0 +1,30 @@
+package strategies
+
+import "strings"
+
+// NormalizePlusAddressOption is the replacement option that strips the "+tag"
+// subaddress from email local parts before hashing, so that
+// john+newsletter@acme.com and john@acme.com share one identity
+const NormalizePlusAddressOption = "normalize_plus_address"
+
+// NormalizePlusAddress removes a "+tag" subaddress from an email address.
+// Values without a subaddress are returned unchanged.
+func NormalizePlusAddress(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+
+	local, domain := email[:at], email[at:]
+	if plus := strings.Index(local, "+"); plus > 0 {
+		local = local[:plus]
+	}
+	return local + domain
+}
+
+// normalizePlusAddressRequested reports whether the options ask for
+// subaddresses to be stripped
+func normalizePlusAddressRequested(options map[string]interface{}) bool {
+	normalize, ok := options[NormalizePlusAddressOption].(bool)
+	return ok && normalize
+}
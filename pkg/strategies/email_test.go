@@ -0,0 +1,17 @@
+package strategies
+
+import "testing"
+
+func TestNormalizePlusAddress(t *testing.T) {
+	tests := map[string]string{
+		"john+newsletter@acme.com": "john@acme.com",
+		"john@acme.com":            "john@acme.com",
+		"+tag@acme.com":            "+tag@acme.com",
+		"not-an-email":             "not-an-email",
+	}
+	for input, expected := range tests {
+		if got := NormalizePlusAddress(input); got != expected {
+			t.Errorf("NormalizePlusAddress(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
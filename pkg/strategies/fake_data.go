@@ -3,7 +3,6 @@ package strategies
 import (
 	"context"
 	"fmt"
-	"strings"
 )
 
 // FakeDataStrategy replaces sensitive data with realistic fake data
@@ -37,7 +36,7 @@ func (s *FakeDataStrategy) Replace(_ context.Context, request *ReplacementReques
 	var replacedText string
 	var confidence = 0.85
 
-	switch strings.ToLower(request.DetectedType) {
+	switch AliasFor(request.DetectedType) {
 	case "name", "person_name":
 		replacedText = s.generateFakeName()
 	case "email":
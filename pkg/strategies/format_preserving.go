@@ -5,8 +5,21 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode"
 )
 
+// PreserveCaseOption is the request option that makes replacements mirror the
+// original's exact case pattern: upper-case letters stay upper-case, lower-case
+// letters stay lower-case and digits stay digits
+const PreserveCaseOption = "preserve_case"
+
+// casePreservingTypes are identifier types whose validation depends on the
+// position of letters and digits, so they always mirror the case pattern
+var casePreservingTypes = map[string]bool{
+	"uk_driving_license":    true,
+	"uk_national_insurance": true,
+}
+
 // FormatPreservingStrategy replaces sensitive data while preserving the original format
 type FormatPreservingStrategy struct {
 	name string
@@ -38,7 +51,13 @@ func (s *FormatPreservingStrategy) Replace(_ context.Context, request *Replaceme
 	var replacedText string
 	var confidence = 0.9
 
-	switch strings.ToLower(request.DetectedType) {
+	detectedType := AliasFor(request.DetectedType)
+	if casePreservingTypes[detectedType] || preserveCaseRequested(request.Options) {
+		replacedText = s.preserveCasePattern(request.OriginalText)
+		return s.result(request, replacedText, confidence), nil
+	}
+
+	switch detectedType {
 	case "ssn", "social_security":
 		replacedText = s.preserveSSNFormat(request.OriginalText)
 	case "phone", "phone_number":
@@ -57,6 +76,11 @@ func (s *FormatPreservingStrategy) Replace(_ context.Context, request *Replaceme
 		confidence = 0.7
 	}
 
+	return s.result(request, replacedText, confidence), nil
+}
+
+// result builds the replacement result for a format-preserved value
+func (s *FormatPreservingStrategy) result(request *ReplacementRequest, replacedText string, confidence float64) *ReplacementResult {
 	return &ReplacementResult{
 		ReplacedText: replacedText,
 		Strategy:     s.name,
@@ -68,7 +92,13 @@ func (s *FormatPreservingStrategy) Replace(_ context.Context, request *Replaceme
 			"format_preserved": true,
 			"detected_type":    request.DetectedType,
 		},
-	}, nil
+	}
+}
+
+// preserveCaseRequested reports whether the options ask for case preservation
+func preserveCaseRequested(options map[string]interface{}) bool {
+	preserve, ok := options[PreserveCaseOption].(bool)
+	return ok && preserve
 }
 
 // IsReversible indicates whether this strategy supports reversible operations
@@ -84,6 +114,7 @@ func (s *FormatPreservingStrategy) GetCapabilities() *StrategyCapabilities {
 			"ssn", "social_security", "phone", "phone_number",
 			"credit_card", "credit_card_number", "date", "date_of_birth",
 			"zip", "postal_code", "account_number",
+			"uk_driving_license", "uk_national_insurance",
 		},
 		SupportsReversible: false,
 		SupportsFormatting: true,
@@ -206,6 +237,27 @@ func (s *FormatPreservingStrategy) preserveGenericFormat(original string) string
 	return result
 }
 
+// preserveCasePattern replaces every letter and digit with a random one of the
+// same class, so the fake satisfies the same positional validation as the
+// original. Non-ASCII letters are mapped to ASCII letters of the same case.
+func (s *FormatPreservingStrategy) preserveCasePattern(original string) string {
+	var result strings.Builder
+	for _, char := range original {
+		switch {
+		case unicode.IsDigit(char):
+			result.WriteRune(rune('0' + randInt(10)))
+		case unicode.IsUpper(char):
+			result.WriteRune(rune('A' + randInt(26)))
+		case unicode.IsLower(char):
+			result.WriteRune(rune('a' + randInt(26)))
+		default:
+			result.WriteRune(char)
+		}
+	}
+
+	return result.String()
+}
+
 func (s *FormatPreservingStrategy) analyzePhoneFormat(phone string) string {
 	// Remove all non-digit characters to count digits
 	digitCount := 0
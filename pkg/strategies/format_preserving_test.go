@@ -0,0 +1,34 @@
+package strategies
+
+import (
+	"context"
+	"regexp"
+	"testing"
+)
+
+func TestFormatPreservingCasePattern(t *testing.T) {
+	strategy := NewFormatPreservingStrategy()
+
+	result, err := strategy.Replace(context.Background(), &ReplacementRequest{
+		OriginalText: "Ab-12-Çd",
+		DetectedType: "customer_ref",
+		Options:      map[string]interface{}{PreserveCaseOption: true},
+	})
+	if err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+	if !regexp.MustCompile(`^[A-Z][a-z]-\d{2}-[A-Z][a-z]$`).MatchString(result.ReplacedText) {
+		t.Errorf("Expected the case pattern to be mirrored, got %q", result.ReplacedText)
+	}
+
+	result, err = strategy.Replace(context.Background(), &ReplacementRequest{
+		OriginalText: "AB123456C",
+		DetectedType: "uk_national_insurance",
+	})
+	if err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+	if !regexp.MustCompile(`^[A-Z]{2}\d{6}[A-Z]$`).MatchString(result.ReplacedText) {
+		t.Errorf("Expected an NI-shaped replacement, got %q", result.ReplacedText)
+	}
+}
@@ -6,27 +6,45 @@ import (
 	"time"
 )
 
-// sharedRNG provides a thread-safe random number generator that is seeded once
+// sharedRNG provides the random number generator used by every strategy. It is
+// seeded from the clock on first use unless SetRNGSeed fixes the sequence.
 var (
 	sharedRNG *rand.Rand
-	rngOnce   sync.Once
+	rngMu     sync.Mutex
 )
 
-// getRNG returns a shared random number generator that is initialized once
-// This prevents the poor randomness issues caused by repeated seeding
+// SetRNGSeed resets the shared random number generator with a fixed seed so
+// that strategy output is reproducible. Intended for tests; production code
+// keeps the time-based seed.
+func SetRNGSeed(seed int64) {
+	rngMu.Lock()
+	defer rngMu.Unlock()
+
+	sharedRNG = rand.New(rand.NewSource(seed))
+}
+
+// getRNG returns the shared random number generator, seeding it once from the
+// clock. This prevents the poor randomness issues caused by repeated seeding.
+// Callers must hold rngMu, since *rand.Rand is not safe for concurrent use.
 func getRNG() *rand.Rand {
-	rngOnce.Do(func() {
+	if sharedRNG == nil {
 		sharedRNG = rand.New(rand.NewSource(time.Now().UnixNano()))
-	})
+	}
 	return sharedRNG
 }
 
 // randInt returns a random integer in the range [0, n)
 func randInt(n int) int {
+	rngMu.Lock()
+	defer rngMu.Unlock()
+
 	return getRNG().Intn(n)
 }
 
 // randIntRange returns a random integer in the range [min, max)
 func randIntRange(minVal, maxVal int) int {
+	rngMu.Lock()
+	defer rngMu.Unlock()
+
 	return getRNG().Intn(maxVal-minVal) + minVal
 }
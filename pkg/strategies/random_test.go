@@ -0,0 +1,39 @@
+package strategies
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestSetRNGSeed(t *testing.T) {
+	strategy := NewFakeDataStrategy()
+
+	fakeNames := func() []string {
+		var names []string
+		for i := 0; i < 4; i++ {
+			result, err := strategy.Replace(context.Background(), &ReplacementRequest{
+				OriginalText: "John Smith",
+				DetectedType: "name",
+			})
+			if err != nil {
+				t.Fatalf("Replace failed: %v", err)
+			}
+			names = append(names, result.ReplacedText)
+		}
+		return names
+	}
+
+	expected := []string{"Sarah Lopez", "Robert Walker", "Donna Johnson", "Jennifer Rodriguez"}
+
+	SetRNGSeed(42)
+	if names := fakeNames(); !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected seeded names %q, got %q", expected, names)
+	}
+
+	// Reseeding replays the same sequence
+	SetRNGSeed(42)
+	if names := fakeNames(); !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected reseeding to replay %q, got %q", expected, names)
+	}
+}
@@ -3,6 +3,7 @@ package strategies
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 )
@@ -12,6 +13,8 @@ type DefaultStrategyRegistry struct {
 	mu         sync.RWMutex
 	strategies map[string]ReplacementStrategy
 	defaults   map[string]string // maps detected type to default strategy name
+	fallback   string            // strategy used for types without a default
+	chain      []string          // strategies tried in order when the default cannot handle a type
 }
 
 // NewDefaultStrategyRegistry creates a new strategy registry with built-in strategies
@@ -19,6 +22,7 @@ func NewDefaultStrategyRegistry() *DefaultStrategyRegistry {
 	registry := &DefaultStrategyRegistry{
 		strategies: make(map[string]ReplacementStrategy),
 		defaults:   make(map[string]string),
+		fallback:   "semantic",
 	}
 
 	// Register built-in strategies
@@ -58,20 +62,22 @@ func (r *DefaultStrategyRegistry) GetStrategy(name string) (ReplacementStrategy,
 	return strategy, nil
 }
 
-// ListStrategies returns all available strategies
+// ListStrategies returns all available strategies, ordered by name
 func (r *DefaultStrategyRegistry) ListStrategies() []ReplacementStrategy {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	strategies := make([]ReplacementStrategy, 0, len(r.strategies))
-	for _, strategy := range r.strategies {
-		strategies = append(strategies, strategy)
+	for _, name := range r.sortedNamesLocked() {
+		strategies = append(strategies, r.strategies[name])
 	}
 
 	return strategies
 }
 
-// GetDefaultStrategy returns the default strategy for a given type
+// GetDefaultStrategy returns the default strategy for a given type. When a
+// fallback chain is set and the type's default cannot handle it, or it has no
+// default, the first strategy in the chain supporting the type is used.
 func (r *DefaultStrategyRegistry) GetDefaultStrategy(detectedType string) (ReplacementStrategy, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -79,21 +85,76 @@ func (r *DefaultStrategyRegistry) GetDefaultStrategy(detectedType string) (Repla
 	// Normalize the detected type
 	normalizedType := strings.ToLower(detectedType)
 
-	// Check if we have a specific default for this type
-	if defaultName, exists := r.defaults[normalizedType]; exists {
-		if strategy, exists := r.strategies[defaultName]; exists {
+	// Check if we have a specific default for this type, then for its canonical alias
+	for _, candidate := range []string{normalizedType, AliasFor(normalizedType)} {
+		if defaultName, exists := r.defaults[candidate]; exists {
+			if strategy, exists := r.strategies[defaultName]; exists {
+				if len(r.chain) == 0 || supportsType(strategy, normalizedType) {
+					return strategy, nil
+				}
+			}
+		}
+	}
+
+	// Try the fallback chain in order
+	for _, name := range r.chain {
+		if strategy, exists := r.strategies[name]; exists && supportsType(strategy, normalizedType) {
 			return strategy, nil
 		}
 	}
 
-	// Fall back to semantic strategy as default
-	if strategy, exists := r.strategies["semantic"]; exists {
+	// Fall back to the configured fallback strategy (semantic unless changed)
+	if strategy, exists := r.strategies[r.fallback]; exists {
 		return strategy, nil
 	}
 
 	return nil, fmt.Errorf("no default strategy available for type '%s'", detectedType)
 }
 
+// SetDefaultStrategy maps a detected type to the named strategy
+func (r *DefaultStrategyRegistry) SetDefaultStrategy(detectedType, strategyName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.strategies[strategyName]; !exists {
+		return fmt.Errorf("strategy '%s' not found", strategyName)
+	}
+
+	r.defaults[strings.ToLower(detectedType)] = strategyName
+	return nil
+}
+
+// SetFallbackStrategy sets the strategy used for types without a default
+func (r *DefaultStrategyRegistry) SetFallbackStrategy(strategyName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.strategies[strategyName]; !exists {
+		return fmt.Errorf("strategy '%s' not found", strategyName)
+	}
+
+	r.fallback = strategyName
+	return nil
+}
+
+// SetFallbackChain sets the strategies tried in order, such as
+// format_preserving, then consistent_hash, then semantic, for types whose
+// default strategy cannot handle them. Types no strategy in the chain supports
+// get the fallback strategy. Calling it with no names clears the chain.
+func (r *DefaultStrategyRegistry) SetFallbackChain(strategyNames ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range strategyNames {
+		if _, exists := r.strategies[name]; !exists {
+			return fmt.Errorf("strategy '%s' not found", name)
+		}
+	}
+
+	r.chain = append([]string(nil), strategyNames...)
+	return nil
+}
+
 // GetBestStrategy returns the best strategy for a given context
 func (r *DefaultStrategyRegistry) GetBestStrategy(_ context.Context, request *StrategySelectionRequest) (ReplacementStrategy, error) {
 	if request == nil {
@@ -135,6 +196,10 @@ func (r *DefaultStrategyRegistry) registerBuiltinStrategies() {
 	fakeData := NewFakeDataStrategy()
 	r.strategies[fakeData.GetName()] = fakeData
 
+	// Register structured placeholder strategy
+	structuredPlaceholder := NewStructuredPlaceholderStrategy()
+	r.strategies[structuredPlaceholder.GetName()] = structuredPlaceholder
+
 	// Set up default mappings
 	r.setupDefaultMappings()
 }
@@ -171,8 +236,10 @@ func (r *DefaultStrategyRegistry) findBestMatch(request *StrategySelectionReques
 	bestStrategy := defaultStrategy
 	bestScore := r.scoreStrategy(defaultStrategy, request)
 
-	// Evaluate all strategies and pick the best one
-	for _, strategy := range r.strategies {
+	// Evaluate all strategies and pick the best one; ties go to the
+	// default, then to the first by name
+	for _, name := range r.sortedNamesLocked() {
+		strategy := r.strategies[name]
 		score := r.scoreStrategy(strategy, request)
 		if score > bestScore {
 			bestScore = score
@@ -188,15 +255,8 @@ func (r *DefaultStrategyRegistry) scoreStrategy(strategy ReplacementStrategy, re
 	capabilities := strategy.GetCapabilities()
 	score := 0.0
 
-	// Check if strategy supports the detected type
-	typeSupported := false
-	for _, supportedType := range capabilities.SupportedTypes {
-		if strings.EqualFold(supportedType, request.DetectedType) {
-			typeSupported = true
-			break
-		}
-	}
-	if !typeSupported {
+	// Check if strategy supports the detected type (or an alias of it)
+	if !supportsType(strategy, request.DetectedType) {
 		return 0.0 // Cannot handle this type
 	}
 
@@ -236,15 +296,35 @@ func (r *DefaultStrategyRegistry) scoreStrategy(strategy ReplacementStrategy, re
 	return score
 }
 
-// GetStrategyNames returns the names of all registered strategies
+// supportsType reports whether a strategy lists the detected type, or an alias
+// of it, among its supported types
+func supportsType(strategy ReplacementStrategy, detectedType string) bool {
+	detectedType = AliasFor(detectedType)
+	for _, supportedType := range strategy.GetCapabilities().SupportedTypes {
+		if AliasFor(supportedType) == detectedType {
+			return true
+		}
+	}
+	return false
+}
+
+// GetStrategyNames returns the names of all registered strategies, sorted
+// alphabetically
 func (r *DefaultStrategyRegistry) GetStrategyNames() []string {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	return r.sortedNamesLocked()
+}
+
+// sortedNamesLocked returns the registered strategy names in alphabetical
+// order. Callers must hold the registry lock.
+func (r *DefaultStrategyRegistry) sortedNamesLocked() []string {
 	names := make([]string, 0, len(r.strategies))
 	for name := range r.strategies {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 
 	return names
 }
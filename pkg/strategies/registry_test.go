@@ -0,0 +1,78 @@
+package strategies
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestRegistryOrdering(t *testing.T) {
+	registry := NewDefaultStrategyRegistry()
+
+	names := registry.GetStrategyNames()
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("Expected sorted strategy names, got %v", names)
+	}
+
+	for i := 0; i < 20; i++ {
+		if again := registry.GetStrategyNames(); !reflect.DeepEqual(again, names) {
+			t.Fatalf("Strategy names changed between calls: %v then %v", names, again)
+		}
+
+		listed := registry.ListStrategies()
+		if len(listed) != len(names) {
+			t.Fatalf("Expected %d strategies, got %d", len(names), len(listed))
+		}
+		for j, strategy := range listed {
+			if strategy.GetName() != names[j] {
+				t.Fatalf("ListStrategies()[%d] = %s, want %s", j, strategy.GetName(), names[j])
+			}
+		}
+	}
+}
+
+func TestFallbackChain(t *testing.T) {
+	registry := NewDefaultStrategyRegistry()
+
+	// Neither format_preserving nor consistent_hash handles companies; fake_data does
+	if err := registry.SetFallbackChain("format_preserving", "consistent_hash", "fake_data"); err != nil {
+		t.Fatalf("SetFallbackChain failed: %v", err)
+	}
+	strategy, err := registry.GetDefaultStrategy("company")
+	if err != nil {
+		t.Fatalf("GetDefaultStrategy failed: %v", err)
+	}
+	if strategy.GetName() != "fake_data" {
+		t.Errorf("Expected fake_data from the chain, got %s", strategy.GetName())
+	}
+
+	// A default that handles its type still wins over the chain
+	if strategy, _ := registry.GetDefaultStrategy("ssn"); strategy.GetName() != "format_preserving" {
+		t.Errorf("Expected the ssn default, got %s", strategy.GetName())
+	}
+
+	// A default that cannot handle its type gives way to the chain
+	if err := registry.SetDefaultStrategy("company", "semantic"); err != nil {
+		t.Fatalf("SetDefaultStrategy failed: %v", err)
+	}
+	if strategy, _ := registry.GetDefaultStrategy("company"); strategy.GetName() != "fake_data" {
+		t.Errorf("Expected the chain to replace the unsupported default, got %s", strategy.GetName())
+	}
+
+	// Types nothing in the chain supports get the fallback strategy
+	if strategy, _ := registry.GetDefaultStrategy("vin"); strategy.GetName() != "semantic" {
+		t.Errorf("Expected the semantic fallback, got %s", strategy.GetName())
+	}
+
+	if err := registry.SetFallbackChain("format_preserving", "missing"); err == nil {
+		t.Error("Expected an error for an unknown strategy in the chain")
+	}
+
+	// Clearing the chain restores the plain defaults
+	if err := registry.SetFallbackChain(); err != nil {
+		t.Fatalf("SetFallbackChain failed: %v", err)
+	}
+	if strategy, _ := registry.GetDefaultStrategy("company"); strategy.GetName() != "semantic" {
+		t.Errorf("Expected the company default without a chain, got %s", strategy.GetName())
+	}
+}
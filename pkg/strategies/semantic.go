@@ -3,7 +3,6 @@ package strategies
 import (
 	"context"
 	"fmt"
-	"strings"
 )
 
 // SemanticStrategy replaces sensitive data with semantically similar but fake data
@@ -37,7 +36,7 @@ func (s *SemanticStrategy) Replace(_ context.Context, request *ReplacementReques
 	var replacedText string
 	var confidence = 0.8
 
-	switch strings.ToLower(request.DetectedType) {
+	switch AliasFor(request.DetectedType) {
 	case "email":
 		replacedText = s.generateFakeEmail()
 	case "phone", "phone_number":
@@ -0,0 +1,111 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// PlaceholderLengthOption selects the length field of structured
+// placeholders: "exact" (the default) gives the length in characters, as in
+// [PHONE:len=12], and "bucket" gives a coarse size, as in [EMAIL:short]
+const PlaceholderLengthOption = "placeholder_length"
+
+// Length buckets used when PlaceholderLengthOption is "bucket"
+const (
+	shortPlaceholderMax  = 8  // Up to 8 characters is "short"
+	mediumPlaceholderMax = 20 // Up to 20 characters is "medium", longer is "long"
+)
+
+// StructuredPlaceholderStrategy replaces sensitive data with a placeholder
+// naming its type and length, so downstream aggregation can reason about the
+// shape of what was removed without seeing the value
+type StructuredPlaceholderStrategy struct {
+	name string
+}
+
+// NewStructuredPlaceholderStrategy creates a new structured placeholder strategy
+func NewStructuredPlaceholderStrategy() *StructuredPlaceholderStrategy {
+	return &StructuredPlaceholderStrategy{
+		name: "structured_placeholder",
+	}
+}
+
+// GetName returns the name of the strategy
+func (s *StructuredPlaceholderStrategy) GetName() string {
+	return s.name
+}
+
+// GetDescription returns a description of the strategy
+func (s *StructuredPlaceholderStrategy) GetDescription() string {
+	return "Replaces sensitive data with a placeholder carrying its type and length"
+}
+
+// Replace performs the replacement using structured placeholder strategy
+func (s *StructuredPlaceholderStrategy) Replace(_ context.Context, request *ReplacementRequest) (*ReplacementResult, error) {
+	if request == nil {
+		return nil, fmt.Errorf("replacement request cannot be nil")
+	}
+
+	label := strings.ToUpper(strings.TrimSpace(request.DetectedType))
+	if label == "" {
+		label = "REDACTED"
+	}
+
+	length := utf8.RuneCountInString(request.OriginalText)
+	var replacedText string
+	switch mode, _ := request.Options[PlaceholderLengthOption].(string); mode {
+	case "", "exact":
+		replacedText = fmt.Sprintf("[%s:len=%d]", label, length)
+	case "bucket":
+		replacedText = fmt.Sprintf("[%s:%s]", label, lengthBucket(length))
+	default:
+		return nil, fmt.Errorf("unknown %s option: %s", PlaceholderLengthOption, mode)
+	}
+
+	return &ReplacementResult{
+		ReplacedText: replacedText,
+		Strategy:     s.name,
+		Confidence:   1.0,
+		Reversible:   false,
+		Metadata: map[string]interface{}{
+			"original_length": length,
+			"detected_type":   request.DetectedType,
+		},
+	}, nil
+}
+
+// IsReversible indicates whether this strategy supports reversible operations
+func (s *StructuredPlaceholderStrategy) IsReversible() bool {
+	return false
+}
+
+// GetCapabilities returns the capabilities of this strategy
+func (s *StructuredPlaceholderStrategy) GetCapabilities() *StrategyCapabilities {
+	return &StrategyCapabilities{
+		Name: s.name,
+		SupportedTypes: []string{
+			"email", "phone", "phone_number", "ssn", "social_security",
+			"credit_card", "credit_card_number", "name", "person_name",
+			"address", "date", "date_of_birth", "generic", "unknown",
+		},
+		SupportsReversible: false,
+		SupportsFormatting: false,
+		RequiresContext:    false,
+		PerformanceLevel:   "fast",
+		AccuracyLevel:      "high",
+	}
+}
+
+// lengthBucket names the size bucket of a value of the given length
+func lengthBucket(length int) string {
+	switch {
+	case length <= shortPlaceholderMax:
+		return "short"
+	case length <= mediumPlaceholderMax:
+		return "medium"
+	default:
+		return "long"
+	}
+}
@@ -0,0 +1,56 @@
+package strategies
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStructuredPlaceholder(t *testing.T) {
+	strategy := NewStructuredPlaceholderStrategy()
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		detectedType string
+		original     string
+		lengthMode   string
+		expected     string
+	}{
+		{"Phone with exact length", "phone", "+1 555 123 4567", "", "[PHONE:len=15]"},
+		{"Email with exact length", "email", "jane@example.com", "exact", "[EMAIL:len=16]"},
+		{"Length counts characters", "name", "Zoë Müller", "", "[NAME:len=10]"},
+		{"Short bucket", "email", "a@b.io", "bucket", "[EMAIL:short]"},
+		{"Medium bucket", "phone", "555-123-4567", "bucket", "[PHONE:medium]"},
+		{"Long bucket", "uk_iban", "GB29 NWBK 6016 1331 9268 19", "bucket", "[UK_IBAN:long]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &ReplacementRequest{OriginalText: tt.original, DetectedType: tt.detectedType}
+			if tt.lengthMode != "" {
+				request.Options = map[string]interface{}{PlaceholderLengthOption: tt.lengthMode}
+			}
+
+			result, err := strategy.Replace(ctx, request)
+			if err != nil {
+				t.Fatalf("Replace failed: %v", err)
+			}
+			if result.ReplacedText != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result.ReplacedText)
+			}
+		})
+	}
+
+	_, err := strategy.Replace(ctx, &ReplacementRequest{
+		OriginalText: "555-123-4567",
+		DetectedType: "phone",
+		Options:      map[string]interface{}{PlaceholderLengthOption: "digits"},
+	})
+	if err == nil {
+		t.Error("Expected an unknown placeholder_length option to be rejected")
+	}
+
+	if _, err := NewDefaultStrategyRegistry().GetStrategy("structured_placeholder"); err != nil {
+		t.Errorf("Expected the strategy to be registered: %v", err)
+	}
+}